@@ -8,6 +8,7 @@ require (
 	github.com/agext/levenshtein v1.2.3 // indirect
 	github.com/aws/aws-sdk-go v1.32.12 // indirect
 	github.com/fatih/color v1.9.0 // indirect
+	github.com/go-resty/resty/v2 v2.3.0
 	github.com/go-test/deep v1.0.6 // indirect
 	github.com/hashicorp/go-getter v1.4.2-0.20200106182914-9813cbd4eb02 // indirect
 	github.com/hashicorp/go-hclog v0.14.1 // indirect
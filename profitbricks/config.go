@@ -1,8 +1,14 @@
 package profitbricks
 
 import (
+	"fmt"
 	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
 
+	resty "github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/httpclient"
 	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
 )
@@ -12,8 +18,89 @@ type Config struct {
 	Username string
 	Password string
 	Endpoint string
-	Retries  int
-	Token    string
+	// Retries is the number of times a GET failing with a server error is retried
+	Retries int
+	Token   string
+	// ContractNumber selects the contract requests are made for, users with several contracts need it
+	ContractNumber string
+	// HTTPTimeout is the timeout of a single http request in seconds
+	HTTPTimeout int
+	// MaxRetries is the number of times a failed http request is retried
+	MaxRetries int
+	// RetryWaitMin and RetryWaitMax limit the seconds to wait between retries
+	RetryWaitMin int
+	RetryWaitMax int
+	// PollInterval is the number of seconds between checks of a request, 0 keeps the defaults
+	PollInterval int
+	// UniqueDatacenterNames rejects a data center named like another one in the same location
+	UniqueDatacenterNames bool
+	// CheckCapacity checks the location and contract can take a server when planning it
+	CheckCapacity bool
+}
+
+// ProviderVersion is reported in the User-Agent, release builds set it with
+// -ldflags "-X github.com/terraform-providers/terraform-provider-profitbricks/profitbricks.ProviderVersion=x.y.z"
+var ProviderVersion = "dev"
+
+// minPollInterval keeps a configured poll interval (in seconds) from hammering the API
+const minPollInterval = 1
+
+// clientSettings keeps the provider settings profitbricks-sdk-go does not know about for each
+// configured client, as resources only get the client as their meta
+var clientSettings sync.Map
+
+type providerSettings struct {
+	PollInterval          time.Duration
+	Retries               int
+	UniqueDatacenterNames bool
+	CheckCapacity         bool
+	Cache                 *lookupCache
+}
+
+// lookupCache keeps the results of lookups which hardly change, like images and locations, for
+// the lifetime of a configured provider, which is a single Terraform run. Nothing is invalidated
+type lookupCache struct {
+	mu      sync.Mutex
+	entries map[string]*lookupCacheEntry
+}
+
+type lookupCacheEntry struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+func newLookupCache() *lookupCache {
+	return &lookupCache{entries: map[string]*lookupCacheEntry{}}
+}
+
+// get returns the cached value of key, calling fetch only once for concurrent lookups of the same key.
+// Failed lookups are not cached, a nil cache always calls fetch
+func (c *lookupCache) get(key string, fetch func() (interface{}, error)) (interface{}, error) {
+	if c == nil {
+		return fetch()
+	}
+
+	c.mu.Lock()
+	if entry, ok := c.entries[key]; ok {
+		c.mu.Unlock()
+		<-entry.done
+		log.Printf("[DEBUG] Using cached %s", key)
+		return entry.value, entry.err
+	}
+	entry := &lookupCacheEntry{done: make(chan struct{})}
+	c.entries[key] = entry
+	c.mu.Unlock()
+
+	entry.value, entry.err = fetch()
+	if entry.err != nil {
+		c.mu.Lock()
+		delete(c.entries, key)
+		c.mu.Unlock()
+	}
+	close(entry.done)
+
+	return entry.value, entry.err
 }
 
 // Client returns a new client for accessing ProfitBricks.
@@ -24,7 +111,7 @@ func (c *Config) Client(terraformVersion string) (*profitbricks.Client, error) {
 	} else {
 		client = profitbricks.NewClient(c.Username, c.Password)
 	}
-	client.SetUserAgent(httpclient.TerraformUserAgent(terraformVersion))
+	client.SetUserAgent(fmt.Sprintf("%s terraform-provider-profitbricks/%s", httpclient.TerraformUserAgent(terraformVersion), ProviderVersion))
 
 	log.Printf("[DEBUG] Terraform client UA set to %s", client.GetUserAgent())
 
@@ -33,5 +120,99 @@ func (c *Config) Client(terraformVersion string) (*profitbricks.Client, error) {
 	if len(c.Endpoint) > 0 {
 		client.SetHostURL(c.Endpoint)
 	}
+
+	client.SetTimeout(time.Duration(c.HTTPTimeout) * time.Second)
+	client.SetRetryCount(c.MaxRetries)
+	client.SetRetryWaitTime(time.Duration(c.RetryWaitMin) * time.Second)
+	client.SetRetryMaxWaitTime(time.Duration(c.RetryWaitMax) * time.Second)
+	client.SetRetryAfter(retryAfter)
+
+	if c.ContractNumber != "" {
+		client.SetHeader("X-Contract-Number", c.ContractNumber)
+	}
+
+	clientSettings.Store(client, providerSettings{
+		PollInterval:          time.Duration(c.PollInterval) * time.Second,
+		Retries:               c.Retries,
+		UniqueDatacenterNames: c.UniqueDatacenterNames,
+		CheckCapacity:         c.CheckCapacity,
+		Cache:                 newLookupCache(),
+	})
+
 	return client, nil
 }
+
+// retryAfter returns how long to wait before retrying a rate limited request, as told by its Retry-After
+// header in seconds or as a date. Returning 0 makes resty back off exponentially from retry_wait_min up
+// to retry_wait_max, which also caps the wait
+func retryAfter(_ *resty.Client, r *resty.Response) (time.Duration, error) {
+	if r.StatusCode() != http.StatusTooManyRequests {
+		return 0, nil
+	}
+
+	value := r.Header().Get("Retry-After")
+	if value == "" {
+		return 0, nil
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		log.Printf("[WARN] Rate limited by the API, retrying in %d seconds", seconds)
+		return time.Duration(seconds) * time.Second, nil
+	}
+	if date, err := http.ParseTime(value); err == nil && time.Until(date) > 0 {
+		log.Printf("[WARN] Rate limited by the API, retrying at %s", date)
+		return time.Until(date), nil
+	}
+
+	return 0, nil
+}
+
+// pollInterval returns the configured time between checks of a request, or fallback if none is configured
+func pollInterval(client *profitbricks.Client, fallback time.Duration) time.Duration {
+	if settings, ok := clientSettings.Load(client); ok && settings.(providerSettings).PollInterval > 0 {
+		return settings.(providerSettings).PollInterval
+	}
+	return fallback
+}
+
+// clientRetries returns how often a failing GET is retried by getWithRetries
+func clientRetries(client *profitbricks.Client) int {
+	if settings, ok := clientSettings.Load(client); ok {
+		return settings.(providerSettings).Retries
+	}
+	return 0
+}
+
+// uniqueDatacenterNames reports whether data center names have to be unique within a location
+func uniqueDatacenterNames(client *profitbricks.Client) bool {
+	if settings, ok := clientSettings.Load(client); ok {
+		return settings.(providerSettings).UniqueDatacenterNames
+	}
+	return false
+}
+
+// checkCapacity reports whether servers are checked against the capacity of their location and contract when planning
+func checkCapacity(client *profitbricks.Client) bool {
+	if settings, ok := clientSettings.Load(client); ok {
+		return settings.(providerSettings).CheckCapacity
+	}
+	return false
+}
+
+// clientCache returns the lookup cache of the provider the client was configured by, or nil
+func clientCache(client *profitbricks.Client) *lookupCache {
+	if settings, ok := clientSettings.Load(client); ok {
+		return settings.(providerSettings).Cache
+	}
+	return nil
+}
+
+// listImages lists all images, once per provider
+func listImages(client *profitbricks.Client) (*profitbricks.Images, error) {
+	images, err := clientCache(client).get("images", func() (interface{}, error) {
+		return client.ListImages()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return images.(*profitbricks.Images), nil
+}
@@ -0,0 +1,109 @@
+package profitbricks
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
+)
+
+// contractResourceLimits are the resource limits of a contract as named by the API, ram is in MB
+// and volume sizes in GB
+var contractResourceLimits = []string{
+	"cores_per_server",
+	"cores_per_contract",
+	"cores_provisioned",
+	"ram_per_server",
+	"ram_per_contract",
+	"ram_provisioned",
+	"hdd_limit_per_volume",
+	"hdd_limit_per_contract",
+	"hdd_volume_provisioned",
+	"ssd_limit_per_volume",
+	"ssd_limit_per_contract",
+	"ssd_volume_provisioned",
+	"reservable_ips",
+	"reserved_ips_on_contract",
+	"reserved_ips_in_use",
+}
+
+func dataSourceContract() *schema.Resource {
+	limits := map[string]*schema.Schema{}
+	for _, name := range contractResourceLimits {
+		limits[name] = &schema.Schema{
+			Type:     schema.TypeInt,
+			Computed: true,
+		}
+	}
+
+	return &schema.Resource{
+		Read: dataSourceContractRead,
+		Schema: map[string]*schema.Schema{
+			"contract_number": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"owner": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"resource_limits": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: limits,
+				},
+			},
+		},
+		Timeouts: &resourceDefaultTimeouts,
+	}
+}
+
+func dataSourceContractRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+
+	contract, err := client.GetContractResources()
+	if err != nil {
+		return fmt.Errorf("An error occured while fetching the contract resources %w", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", contract.Properties.PBContractNumber))
+	d.Set("contract_number", contract.Properties.PBContractNumber)
+	d.Set("owner", contract.Properties.Owner)
+	d.Set("status", contract.Properties.Status)
+
+	if err := d.Set("resource_limits", flattenContractResourceLimits(contract.Properties.ResourceLimits)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func flattenContractResourceLimits(limits *profitbricks.ResourcesLimits) []map[string]interface{} {
+	if limits == nil {
+		return []map[string]interface{}{}
+	}
+	return []map[string]interface{}{
+		{
+			"cores_per_server":         limits.CoresPerServer,
+			"cores_per_contract":       limits.CoresPerContract,
+			"cores_provisioned":        limits.CoresProvisioned,
+			"ram_per_server":           limits.RAMPerServer,
+			"ram_per_contract":         limits.RAMPerContract,
+			"ram_provisioned":          limits.RAMProvisioned,
+			"hdd_limit_per_volume":     limits.HddLimitPerVolume,
+			"hdd_limit_per_contract":   limits.HddLimitPerContract,
+			"hdd_volume_provisioned":   limits.HddVolumeProvisioned,
+			"ssd_limit_per_volume":     limits.SsdLimitPerVolume,
+			"ssd_limit_per_contract":   limits.SsdLimitPerContract,
+			"ssd_volume_provisioned":   limits.SsdVolumeProvisioned,
+			"reservable_ips":           limits.ReservableIps,
+			"reserved_ips_on_contract": limits.ReservedIpsOnContract,
+			"reserved_ips_in_use":      limits.ReservedIpsInUse,
+		},
+	}
+}
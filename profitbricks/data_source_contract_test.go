@@ -0,0 +1,36 @@
+package profitbricks
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceContract_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceProfitBricksContract_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.profitbricks_contract.contract", "contract_number"),
+					resource.TestCheckResourceAttrSet("data.profitbricks_contract.contract", "owner"),
+					resource.TestCheckResourceAttr("data.profitbricks_contract.contract", "status", "BILLABLE"),
+					resource.TestCheckResourceAttrSet("data.profitbricks_contract.contract", "resource_limits.0.cores_per_server"),
+					resource.TestCheckResourceAttrPair("data.profitbricks_contract.contract", "resource_limits.0.cores_provisioned", "data.profitbricks_quota.contract", "cores_provisioned"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceProfitBricksContract_basic = `
+data "profitbricks_contract" "contract" {
+}
+
+data "profitbricks_quota" "contract" {
+}
+`
@@ -27,6 +27,11 @@ func dataSourceDataCenter() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"labels": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 		},
 		Timeouts: &resourceDefaultTimeouts,
 	}
@@ -115,5 +120,9 @@ func dataSourceDataCenterRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("location", datacenter.Properties.Location)
 	d.Set("name", datacenter.Properties.Name)
 
+	if err := readLabels(client, d, datacenterLabelsPath(datacenter.ID)); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -22,6 +22,8 @@ func TestAccDataSourceDatacenter_matching(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("data.profitbricks_datacenter.foobar", "name", "test_name"),
 					resource.TestCheckResourceAttr("data.profitbricks_datacenter.foobar", "location", "us/las"),
+					resource.TestCheckResourceAttr("data.profitbricks_datacenter.foobar", "labels.%", "1"),
+					resource.TestCheckResourceAttr("data.profitbricks_datacenter.foobar", "labels.env", "test"),
 				),
 			},
 		},
@@ -33,6 +35,9 @@ const testAccDataSourceProfitBricksDataCenter_matching = `
 resource "profitbricks_datacenter" "foobar" {
     name       = "test_name"
     location = "us/las"
+    labels = {
+        env = "test"
+    }
 }
 `
 
@@ -40,6 +45,9 @@ const testAccDataSourceProfitBricksDataCenter_matchingWithDataSource = `
 resource "profitbricks_datacenter" "foobar" {
     name       = "test_name"
     location = "us/las"
+    labels = {
+        env = "test"
+    }
 }
 
 data "profitbricks_datacenter" "foobar" {
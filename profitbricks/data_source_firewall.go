@@ -0,0 +1,132 @@
+package profitbricks
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
+)
+
+func dataSourceFirewall() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceFirewallRead,
+		Schema: map[string]*schema.Schema{
+			"datacenter_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"server_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"nic_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"protocol": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"source_mac": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"source_ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"target_ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"port_range_start": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"port_range_end": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"icmp_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"icmp_code": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+		Timeouts: &resourceDefaultTimeouts,
+	}
+}
+
+func dataSourceFirewallRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+
+	dcId := d.Get("datacenter_id").(string)
+	serverId := d.Get("server_id").(string)
+	nicId := d.Get("nic_id").(string)
+
+	// the ids are often interpolated, so an empty one only shows up at read time
+	for attr, value := range map[string]string{"datacenter_id": dcId, "server_id": serverId, "nic_id": nicId} {
+		if value == "" {
+			return fmt.Errorf("'%s' must not be empty, a firewall rule is looked up by datacenter_id, server_id and nic_id", attr)
+		}
+	}
+
+	firewallRules, err := client.ListFirewallRules(dcId, serverId, nicId)
+
+	if err != nil {
+		return fmt.Errorf("An error occured while fetching the firewall rules of nic %s (datacenter %s, server %s): %s", nicId, dcId, serverId, err)
+	}
+
+	name := d.Get("name").(string)
+	results := []profitbricks.FirewallRule{}
+
+	for _, fw := range firewallRules.Items {
+		if fw.Properties.Name == name {
+			results = append(results, fw)
+		}
+	}
+
+	if len(results) > 1 {
+		return fmt.Errorf("There is more than one firewall rule named %s on nic %s", name, nicId)
+	}
+
+	if len(results) == 0 {
+		return fmt.Errorf("There are no firewall rules named %s on nic %s", name, nicId)
+	}
+
+	fw := results[0]
+	d.SetId(fw.ID)
+	d.Set("protocol", fw.Properties.Protocol)
+
+	if fw.Properties.SourceMac != nil {
+		d.Set("source_mac", *fw.Properties.SourceMac)
+	}
+	if fw.Properties.SourceIP != nil {
+		d.Set("source_ip", *fw.Properties.SourceIP)
+	}
+	if fw.Properties.TargetIP != nil {
+		d.Set("target_ip", *fw.Properties.TargetIP)
+	}
+	if fw.Properties.PortRangeStart != nil {
+		d.Set("port_range_start", *fw.Properties.PortRangeStart)
+	}
+	if fw.Properties.PortRangeEnd != nil {
+		d.Set("port_range_end", *fw.Properties.PortRangeEnd)
+	}
+	if fw.Properties.IcmpType != nil {
+		d.Set("icmp_type", strconv.Itoa(*fw.Properties.IcmpType))
+	}
+	if fw.Properties.IcmpCode != nil {
+		d.Set("icmp_code", strconv.Itoa(*fw.Properties.IcmpCode))
+	}
+
+	return nil
+}
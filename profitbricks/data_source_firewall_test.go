@@ -0,0 +1,40 @@
+package profitbricks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceFirewall_matching(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckProfitbricksFirewallConfig_basic, "http"),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckProfitbricksFirewallConfig_basic, "http") + testAccDataSourceProfitBricksFirewall_matching,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.profitbricks_firewall.http", "id", "profitbricks_firewall.webserver_http", "id"),
+					resource.TestCheckResourceAttr("data.profitbricks_firewall.http", "protocol", "TCP"),
+					resource.TestCheckResourceAttr("data.profitbricks_firewall.http", "port_range_start", "80"),
+					resource.TestCheckResourceAttr("data.profitbricks_firewall.http", "port_range_end", "80"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceProfitBricksFirewall_matching = `
+
+data "profitbricks_firewall" "http" {
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  server_id = "${profitbricks_server.webserver.id}"
+  nic_id = "${profitbricks_nic.database_nic.id}"
+  name = "${profitbricks_firewall.webserver_http.name}"
+}`
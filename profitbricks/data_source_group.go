@@ -0,0 +1,139 @@
+package profitbricks
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
+)
+
+func dataSourceGroup() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGroupRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"create_datacenter": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"create_snapshot": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"reserve_ip": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"access_activity_log": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"create_backup_unit": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"create_internet_access": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"create_k8s_cluster": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"create_pcc": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"s3_privilege": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"users": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+		},
+		Timeouts: &resourceDefaultTimeouts,
+	}
+}
+
+func dataSourceGroupRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+
+	id, idOk := d.GetOk("id")
+	name, nameOk := d.GetOk("name")
+
+	if !idOk && !nameOk {
+		return fmt.Errorf("Either id or name has to be provided to look up a group")
+	}
+
+	var group *profitbricks.Group
+
+	if idOk {
+		found, err := client.GetGroup(id.(string))
+		if err != nil {
+			return fmt.Errorf("An error occured while fetching group %s: %s", id.(string), err)
+		}
+		if nameOk && found.Properties.Name != name.(string) {
+			return fmt.Errorf("Group %s is named %s, not %s", id.(string), found.Properties.Name, name.(string))
+		}
+		group = found
+	} else {
+		groups, err := client.ListGroups()
+		if err != nil {
+			return fmt.Errorf("An error occured while fetching ProfitBricks groups %s", err)
+		}
+
+		results := []profitbricks.Group{}
+		for _, g := range groups.Items {
+			if g.Properties.Name == name.(string) {
+				results = append(results, g)
+			}
+		}
+
+		if len(results) > 1 {
+			return fmt.Errorf("There is more than one group named %s, please use its id instead", name.(string))
+		}
+
+		if len(results) == 0 {
+			return fmt.Errorf("There are no groups named %s", name.(string))
+		}
+
+		group = &results[0]
+	}
+
+	d.SetId(group.ID)
+	d.Set("name", group.Properties.Name)
+	d.Set("create_datacenter", group.Properties.CreateDataCenter)
+	d.Set("create_snapshot", group.Properties.CreateSnapshot)
+	d.Set("reserve_ip", group.Properties.ReserveIP)
+	d.Set("access_activity_log", group.Properties.AccessActivityLog)
+	d.Set("create_backup_unit", group.Properties.CreateBackupUnit)
+	d.Set("create_internet_access", group.Properties.CreateInternetAccess)
+	d.Set("create_k8s_cluster", group.Properties.CreateK8sCluster)
+	d.Set("create_pcc", group.Properties.CreatePcc)
+	d.Set("s3_privilege", group.Properties.S3Privilege)
+
+	users, err := client.ListGroupUsers(group.ID)
+	if err != nil {
+		return fmt.Errorf("An error occured while fetching the users of group %s: %s", group.ID, err)
+	}
+
+	userIds := []string{}
+	for _, user := range users.Items {
+		userIds = append(userIds, user.ID)
+	}
+	d.Set("users", userIds)
+
+	return nil
+}
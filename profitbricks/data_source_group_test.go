@@ -0,0 +1,55 @@
+package profitbricks
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceGroup_matching(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+
+				Config: testAccDataSourceProfitBricksGroup_matching,
+			},
+			{
+				Config: testAccDataSourceProfitBricksGroup_matchingWithDataSource,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.profitbricks_group.by_name", "id", "profitbricks_group.foobar", "id"),
+					resource.TestCheckResourceAttr("data.profitbricks_group.by_name", "create_datacenter", "true"),
+					resource.TestCheckResourceAttr("data.profitbricks_group.by_name", "access_activity_log", "false"),
+					resource.TestCheckResourceAttrPair("data.profitbricks_group.by_id", "name", "profitbricks_group.foobar", "name"),
+				),
+			},
+		},
+	})
+
+}
+
+const testAccDataSourceProfitBricksGroup_matching = `
+resource "profitbricks_group" "foobar" {
+  name = "datasource_test_group"
+  create_datacenter = true
+  access_activity_log = false
+}
+`
+
+const testAccDataSourceProfitBricksGroup_matchingWithDataSource = `
+resource "profitbricks_group" "foobar" {
+  name = "datasource_test_group"
+  create_datacenter = true
+  access_activity_log = false
+}
+
+data "profitbricks_group" "by_name" {
+  name = "${profitbricks_group.foobar.name}"
+}
+
+data "profitbricks_group" "by_id" {
+  id = "${profitbricks_group.foobar.id}"
+}`
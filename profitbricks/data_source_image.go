@@ -2,6 +2,7 @@ package profitbricks
 
 import (
 	"fmt"
+	"log"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -19,6 +20,12 @@ func dataSourceImage() *schema.Resource {
 			"type": {
 				Type:     schema.TypeString,
 				Optional: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if v.(string) != "HDD" && v.(string) != "CDROM" {
+						errors = append(errors, fmt.Errorf("%q must be HDD or CDROM, got %q", k, v.(string)))
+					}
+					return
+				},
 			},
 			"location": {
 				Type:     schema.TypeString,
@@ -28,6 +35,25 @@ func dataSourceImage() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"image_alias": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"most_recent": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Use the most recently created image when several match, instead of failing",
+			},
+			"created_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"image_aliases": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 		},
 		Timeouts: &resourceDefaultTimeouts,
 	}
@@ -36,7 +62,7 @@ func dataSourceImage() *schema.Resource {
 func dataSourceImageRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*profitbricks.Client)
 
-	images, err := client.ListImages()
+	images, err := listImages(client)
 
 	if err != nil {
 		return fmt.Errorf("An error occured while fetching ProfitBricks images %s", err)
@@ -46,6 +72,7 @@ func dataSourceImageRead(d *schema.ResourceData, meta interface{}) error {
 	imageType, imageTypeOk := d.GetOk("type")
 	location, locationOk := d.GetOk("location")
 	version, versionOk := d.GetOk("version")
+	imageAlias, imageAliasOk := d.GetOk("image_alias")
 
 	results := []profitbricks.Image{}
 
@@ -88,8 +115,36 @@ func dataSourceImageRead(d *schema.ResourceData, meta interface{}) error {
 		results = locationResults
 	}
 
+	if imageAliasOk {
+		imageAliasResults := []profitbricks.Image{}
+		for _, img := range results {
+			for _, alias := range img.Properties.ImageAliases {
+				if alias == imageAlias.(string) {
+					imageAliasResults = append(imageAliasResults, img)
+					break
+				}
+			}
+		}
+		results = imageAliasResults
+
+		// an alias may be shared by the images of several locations, the most recent one is used
+		if len(results) > 1 {
+			mostRecent := mostRecentImage(results)
+			log.Printf("[WARN] More than one image matches the image alias %q, using the most recent one %s. Other matches: %s", imageAlias.(string), mostRecent.ID, strings.Join(otherImages(results, mostRecent), ", "))
+
+			results = []profitbricks.Image{mostRecent}
+		}
+	}
+
+	if len(results) > 1 && d.Get("most_recent").(bool) {
+		mostRecent := mostRecentImage(results)
+		log.Printf("[INFO] Using the most recent image %s (%s), other matches: %s", mostRecent.ID, mostRecent.Properties.Name, strings.Join(otherImages(results, mostRecent), ", "))
+
+		results = []profitbricks.Image{mostRecent}
+	}
+
 	if len(results) > 1 {
-		return fmt.Errorf("There is more than one image that match the search criteria")
+		return fmt.Errorf("There is more than one image that match the search criteria, set 'most_recent' to use the most recent one: %s", strings.Join(otherImages(results, profitbricks.Image{}), ", "))
 	}
 
 	if len(results) == 0 {
@@ -97,8 +152,39 @@ func dataSourceImageRead(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	d.Set("name", results[0].Properties.Name)
+	d.Set("created_date", imageCreatedDate(results[0]))
+	d.Set("image_aliases", results[0].Properties.ImageAliases)
 
 	d.SetId(results[0].ID)
 
 	return nil
 }
+
+func imageCreatedDate(img profitbricks.Image) string {
+	if img.Metadata == nil {
+		return ""
+	}
+	return img.Metadata.CreatedDate
+}
+
+// mostRecentImage returns the most recently created of images, the first one of several created at the same time
+func mostRecentImage(images []profitbricks.Image) profitbricks.Image {
+	mostRecent := images[0]
+	for _, img := range images[1:] {
+		if imageCreatedDate(img) > imageCreatedDate(mostRecent) {
+			mostRecent = img
+		}
+	}
+	return mostRecent
+}
+
+// otherImages describes the images other than img, to tell which ones matched as well
+func otherImages(images []profitbricks.Image, img profitbricks.Image) []string {
+	others := []string{}
+	for _, other := range images {
+		if other.ID != img.ID {
+			others = append(others, fmt.Sprintf("%s (%s, %s)", other.ID, other.Properties.Name, other.Properties.Location))
+		}
+	}
+	return others
+}
@@ -2,9 +2,11 @@ package profitbricks
 
 import (
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
 )
 
 func TestAccDataSourceImage_basic(t *testing.T) {
@@ -29,6 +31,57 @@ func TestAccDataSourceImage_basic(t *testing.T) {
 
 }
 
+func TestAccDataSourceImage_imageAlias(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceProfitBricksImage_imageAlias,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.profitbricks_image.img", "location", "us/las"),
+					resource.TestCheckResourceAttrSet("data.profitbricks_image.img", "image_aliases.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceImage_mostRecent(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      strings.Replace(testAccDataSourceProfitBricksImage_mostRecent, "most_recent = true", "", 1),
+				ExpectError: regexp.MustCompile(`set 'most_recent' to use the most recent one`),
+			},
+			{
+				Config: testAccDataSourceProfitBricksImage_mostRecent,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.profitbricks_image.img", "name", regexp.MustCompile("(?i)ubuntu")),
+					resource.TestCheckResourceAttrSet("data.profitbricks_image.img", "created_date"),
+				),
+			},
+		},
+	})
+}
+
+func TestMostRecentImage(t *testing.T) {
+	images := []profitbricks.Image{
+		{ID: "old", Metadata: &profitbricks.Metadata{CreatedDate: "2019-01-01T00:00:00Z"}},
+		{ID: "new", Metadata: &profitbricks.Metadata{CreatedDate: "2020-06-01T00:00:00Z"}},
+		{ID: "unknown"},
+	}
+	if img := mostRecentImage(images); img.ID != "new" {
+		t.Fatalf("expected the most recent image new, got %s", img.ID)
+	}
+}
+
 const testAccDataSourceProfitBricksImage_basic = `
 	data "profitbricks_image" "img" {
 	  name = "Ubuntu"
@@ -37,3 +90,20 @@ const testAccDataSourceProfitBricksImage_basic = `
 	  location = "us/las"
 	}
 `
+
+const testAccDataSourceProfitBricksImage_imageAlias = `
+	data "profitbricks_image" "img" {
+	  image_alias = "ubuntu:latest"
+	  type = "HDD"
+	  location = "us/las"
+	}
+`
+
+const testAccDataSourceProfitBricksImage_mostRecent = `
+	data "profitbricks_image" "img" {
+	  name = "Ubuntu"
+	  type = "HDD"
+	  location = "de/fra"
+	  most_recent = true
+	}
+`
@@ -0,0 +1,144 @@
+package profitbricks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
+)
+
+func dataSourceIPBlock() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIPBlockRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"location": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"ips": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"cidrs": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"ip_consumers": ipConsumersSchema(),
+		},
+		Timeouts: &resourceDefaultTimeouts,
+	}
+}
+
+func dataSourceIPBlockRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+
+	ipblocks, err := client.ListIPBlocks()
+
+	if err != nil {
+		return fmt.Errorf("An error occured while fetching ProfitBricks ip blocks %s", err)
+	}
+
+	name, nameOk := d.GetOk("name")
+	location, locationOk := d.GetOk("location")
+	results := []profitbricks.IPBlock{}
+
+	for _, ipblock := range ipblocks.Items {
+		if nameOk && !strings.Contains(strings.ToLower(ipblock.Properties.Name), strings.ToLower(name.(string))) {
+			continue
+		}
+		if locationOk && ipblock.Properties.Location != location.(string) {
+			continue
+		}
+		results = append(results, ipblock)
+	}
+
+	if len(results) > 1 {
+		return fmt.Errorf("There is more than one ip block that match the search criteria, please narrow it down using name and/or location")
+	}
+
+	if len(results) == 0 {
+		return fmt.Errorf("There are no ip blocks that match the search criteria")
+	}
+
+	d.SetId(results[0].ID)
+	d.Set("name", results[0].Properties.Name)
+	d.Set("location", results[0].Properties.Location)
+	d.Set("size", results[0].Properties.Size)
+	d.Set("ips", results[0].Properties.IPs)
+	d.Set("cidrs", ipv4CIDRs(results[0].Properties.IPs))
+
+	if err := d.Set("ip_consumers", flattenIPConsumers(results[0].Properties.IPConsumers)); err != nil {
+		return fmt.Errorf("Error while setting ip_consumers of ip block %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// ipConsumersSchema describes the resources which currently hold ips of an ip block
+func ipConsumersSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"ip": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"mac": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"nic_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"server_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"server_name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"datacenter_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"datacenter_name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func flattenIPConsumers(consumers []profitbricks.IPConsumer) []map[string]string {
+	result := []map[string]string{}
+	for _, consumer := range consumers {
+		result = append(result, map[string]string{
+			"ip":              consumer.IP,
+			"mac":             consumer.Mac,
+			"nic_id":          consumer.NicID,
+			"server_id":       consumer.ServerID,
+			"server_name":     consumer.ServerName,
+			"datacenter_id":   consumer.DatacenterID,
+			"datacenter_name": consumer.DatacenterName,
+		})
+	}
+	return result
+}
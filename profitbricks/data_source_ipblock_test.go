@@ -0,0 +1,51 @@
+package profitbricks
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceIPBlock_matching(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+
+				Config: testAccDataSourceProfitBricksIPBlock_matching,
+			},
+			{
+				Config: testAccDataSourceProfitBricksIPBlock_matchingWithDataSource,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.profitbricks_ipblock.foobar", "id", "profitbricks_ipblock.foobar", "id"),
+					resource.TestCheckResourceAttr("data.profitbricks_ipblock.foobar", "size", "1"),
+					resource.TestCheckResourceAttr("data.profitbricks_ipblock.foobar", "ips.#", "1"),
+				),
+			},
+		},
+	})
+
+}
+
+const testAccDataSourceProfitBricksIPBlock_matching = `
+resource "profitbricks_ipblock" "foobar" {
+    name     = "datasource_test_ipblock"
+    location = "us/las"
+    size     = 1
+}
+`
+
+const testAccDataSourceProfitBricksIPBlock_matchingWithDataSource = `
+resource "profitbricks_ipblock" "foobar" {
+    name     = "datasource_test_ipblock"
+    location = "us/las"
+    size     = 1
+}
+
+data "profitbricks_ipblock" "foobar" {
+    name     = "${profitbricks_ipblock.foobar.name}"
+    location = "us/las"
+}`
@@ -0,0 +1,163 @@
+package profitbricks
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
+)
+
+func dataSourceK8sCluster() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceK8sClusterRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"k8s_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"maintenance_window": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"day_of_the_week": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"public": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"api_subnet_allow_list": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"available_upgrade_versions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"viable_node_pool_versions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"kube_config": {
+				Type:        schema.TypeString,
+				Description: "The kubeconfig file contents for the cluster, only set when the cluster is ACTIVE",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+		Timeouts: &resourceDefaultTimeouts,
+	}
+}
+
+func dataSourceK8sClusterRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+
+	id, idOk := d.GetOk("id")
+	name, nameOk := d.GetOk("name")
+	if !idOk && !nameOk {
+		return fmt.Errorf("either id or name must be set")
+	}
+
+	var cluster *k8sClusterWithExtras
+	if idOk {
+		found, err := getK8sClusterWithExtras(client, id.(string))
+		if err != nil {
+			return fmt.Errorf("An error occured while fetching the k8s cluster %s %w", id.(string), err)
+		}
+		if nameOk && found.Properties.Name != name.(string) {
+			return fmt.Errorf("The name of the k8s cluster %s is %s, not %s", found.ID, found.Properties.Name, name.(string))
+		}
+		cluster = found
+	} else {
+		clusters, err := listK8sClustersWithExtras(client)
+		if err != nil {
+			return fmt.Errorf("An error occured while fetching the k8s clusters %w", err)
+		}
+
+		results := []k8sClusterWithExtras{}
+		for _, c := range clusters.Items {
+			if c.Properties.Name == name.(string) {
+				results = append(results, c)
+			}
+		}
+
+		if len(results) > 1 {
+			return fmt.Errorf("There is more than one k8s cluster named %s", name.(string))
+		}
+		if len(results) == 0 {
+			return fmt.Errorf("There are no k8s clusters named %s", name.(string))
+		}
+		cluster = &results[0]
+	}
+
+	log.Printf("[INFO] Got k8s cluster %s (%s)", cluster.Properties.Name, cluster.ID)
+
+	d.SetId(cluster.ID)
+	d.Set("name", cluster.Properties.Name)
+	d.Set("k8s_version", cluster.Properties.K8sVersion)
+	if err := d.Set("maintenance_window", flattenMaintenanceWindow(cluster.Properties.MaintenanceWindow)); err != nil {
+		return err
+	}
+	public := true
+	if cluster.Properties.Public != nil {
+		public = *cluster.Properties.Public
+	}
+	d.Set("public", public)
+	apiSubnetAllowList := []string{}
+	if cluster.Properties.APISubnetAllowList != nil {
+		apiSubnetAllowList = *cluster.Properties.APISubnetAllowList
+	}
+	if err := d.Set("api_subnet_allow_list", apiSubnetAllowList); err != nil {
+		return err
+	}
+	if err := d.Set("available_upgrade_versions", cluster.Properties.AvailableUpgradeVersions); err != nil {
+		return err
+	}
+	if err := d.Set("viable_node_pool_versions", cluster.Properties.ViableNodePoolVersions); err != nil {
+		return err
+	}
+
+	state, kubeConfig := "", ""
+	if cluster.Metadata != nil {
+		state = cluster.Metadata.State
+	}
+	// the kubeconfig is only available once the cluster is provisioned
+	if state == "ACTIVE" {
+		config, err := client.GetKubeconfig(cluster.ID)
+		if err != nil {
+			return fmt.Errorf("Error while fetching kubeconfig for k8s cluster %s: %w", cluster.ID, err)
+		}
+		kubeConfig = config
+	}
+	d.Set("state", state)
+	d.Set("kube_config", kubeConfig)
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package profitbricks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceK8sCluster_matching(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksk8sClusterDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckProfitBricksk8sClusterConfigBasic, "k8s-data-source"),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckProfitBricksk8sClusterConfigBasic, "k8s-data-source") + testAccDataSourceProfitBricksK8sCluster_matching,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.profitbricks_k8s_cluster.by_name", "id", "profitbricks_k8s_cluster.example", "id"),
+					resource.TestCheckResourceAttr("data.profitbricks_k8s_cluster.by_name", "state", "ACTIVE"),
+					resource.TestCheckResourceAttr("data.profitbricks_k8s_cluster.by_name", "maintenance_window.0.day_of_the_week", "Sunday"),
+					resource.TestCheckResourceAttrPair("data.profitbricks_k8s_cluster.by_name", "kube_config", "profitbricks_k8s_cluster.example", "kube_config"),
+					resource.TestCheckResourceAttr("data.profitbricks_k8s_cluster.by_id", "name", "k8s-data-source"),
+					resource.TestCheckResourceAttrSet("data.profitbricks_k8s_cluster.by_id", "viable_node_pool_versions.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceProfitBricksK8sCluster_matching = `
+
+data "profitbricks_k8s_cluster" "by_name" {
+  name = "${profitbricks_k8s_cluster.example.name}"
+}
+
+data "profitbricks_k8s_cluster" "by_id" {
+  id = "${profitbricks_k8s_cluster.example.id}"
+}`
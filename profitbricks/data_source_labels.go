@@ -0,0 +1,113 @@
+package profitbricks
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
+)
+
+func dataSourceLabels() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceLabelsRead,
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return labels with this key.",
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return labels with this value.",
+			},
+			"resource_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return labels attached to resources of this type, e.g. datacenter or server.",
+			},
+			"labels": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+		Timeouts: &resourceDefaultTimeouts,
+	}
+}
+
+func dataSourceLabelsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+
+	items, err := listLabels(client, "/labels")
+	if err != nil {
+		return fmt.Errorf("An error occured while fetching labels %w", err)
+	}
+
+	key := d.Get("key").(string)
+	value, valueOk := d.GetOk("value")
+	resourceType := d.Get("resource_type").(string)
+
+	labels := []map[string]interface{}{}
+	ids := []string{}
+	for _, l := range items {
+		if key != "" && l.Properties.Key != key {
+			continue
+		}
+		if valueOk && l.Properties.Value != value.(string) {
+			continue
+		}
+		if resourceType != "" && l.Properties.ResourceType != resourceType {
+			continue
+		}
+
+		labels = append(labels, map[string]interface{}{
+			"id":            l.ID,
+			"key":           l.Properties.Key,
+			"value":         l.Properties.Value,
+			"resource_type": l.Properties.ResourceType,
+			"resource_id":   l.Properties.ResourceID,
+			"resource_href": l.Properties.ResourceHref,
+		})
+		ids = append(ids, l.ID)
+	}
+
+	log.Printf("[INFO] Found %d of %d labels", len(labels), len(items))
+
+	d.SetId(fmt.Sprintf("%d", hashcode.String(strings.Join(ids, ","))))
+	if err := d.Set("labels", labels); err != nil {
+		return err
+	}
+
+	return nil
+}
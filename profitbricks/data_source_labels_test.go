@@ -0,0 +1,47 @@
+package profitbricks
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceLabels_key(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceProfitBricksLabels_resources,
+			},
+			{
+				Config: testAccDataSourceProfitBricksLabels_key,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.profitbricks_labels.inventory", "labels.#", "1"),
+					resource.TestCheckResourceAttr("data.profitbricks_labels.inventory", "labels.0.key", "inventory_test"),
+					resource.TestCheckResourceAttr("data.profitbricks_labels.inventory", "labels.0.value", "1234"),
+					resource.TestCheckResourceAttr("data.profitbricks_labels.inventory", "labels.0.resource_type", "datacenter"),
+					resource.TestCheckResourceAttrPair("data.profitbricks_labels.inventory", "labels.0.resource_id", "profitbricks_datacenter.foobar", "id"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceProfitBricksLabels_resources = `
+resource "profitbricks_datacenter" "foobar" {
+    name       = "labels_test"
+    location = "us/las"
+    labels = {
+        inventory_test = "1234"
+    }
+}
+`
+
+const testAccDataSourceProfitBricksLabels_key = testAccDataSourceProfitBricksLabels_resources + `
+data "profitbricks_labels" "inventory" {
+    key = "inventory_test"
+    resource_type = "datacenter"
+}`
@@ -0,0 +1,125 @@
+package profitbricks
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
+)
+
+func dataSourceLan() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceLanRead,
+		Schema: map[string]*schema.Schema{
+			"datacenter_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"public": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"pcc": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ip_failover": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"nic_uuid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ip": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+		Timeouts: &resourceDefaultTimeouts,
+	}
+}
+
+func dataSourceLanRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+
+	dcId := d.Get("datacenter_id").(string)
+	if dcId == "" {
+		return fmt.Errorf("'datacenter_id' must not be empty, a LAN is looked up in a data center")
+	}
+
+	id, idOk := d.GetOk("id")
+	name, nameOk := d.GetOk("name")
+	if !idOk && !nameOk {
+		return fmt.Errorf("either id or name must be set")
+	}
+
+	var lan *profitbricks.Lan
+	if idOk {
+		found, err := client.GetLan(dcId, id.(string))
+		if err != nil {
+			return fmt.Errorf("An error occured while fetching the LAN %s in data center %s %w", id.(string), dcId, err)
+		}
+		if nameOk && found.Properties.Name != name.(string) {
+			return fmt.Errorf("The name of the LAN %s is %s, not %s", found.ID, found.Properties.Name, name.(string))
+		}
+		lan = found
+	} else {
+		lans, err := client.ListLans(dcId)
+		if err != nil {
+			return fmt.Errorf("An error occured while fetching the LANs of data center %s %w", dcId, err)
+		}
+
+		results := []profitbricks.Lan{}
+		for _, l := range lans.Items {
+			if l.Properties.Name == name.(string) {
+				results = append(results, l)
+			}
+		}
+
+		if len(results) > 1 {
+			return fmt.Errorf("There is more than one LAN named %s in data center %s", name.(string), dcId)
+		}
+		if len(results) == 0 {
+			return fmt.Errorf("There are no LANs named %s in data center %s", name.(string), dcId)
+		}
+		lan = &results[0]
+	}
+
+	log.Printf("[INFO] Got LAN %s (%s)", lan.Properties.Name, lan.ID)
+
+	d.SetId(lan.ID)
+	d.Set("name", lan.Properties.Name)
+	d.Set("public", lan.Properties.Public)
+	d.Set("pcc", lan.Properties.PCC)
+
+	ipFailover := []map[string]interface{}{}
+	if lan.Properties.IPFailover != nil {
+		for _, group := range *lan.Properties.IPFailover {
+			ipFailover = append(ipFailover, map[string]interface{}{
+				"nic_uuid": group.NicUUID,
+				"ip":       group.IP,
+			})
+		}
+	}
+	if err := d.Set("ip_failover", ipFailover); err != nil {
+		return err
+	}
+
+	return nil
+}
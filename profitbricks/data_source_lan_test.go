@@ -0,0 +1,43 @@
+package profitbricks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceLan_matching(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckProfitbricksLanConfig_basic, "public"),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckProfitbricksLanConfig_basic, "public") + testAccDataSourceProfitBricksLan_matching,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.profitbricks_lan.by_name", "id", "profitbricks_lan.webserver_lan", "id"),
+					resource.TestCheckResourceAttr("data.profitbricks_lan.by_name", "public", "true"),
+					resource.TestCheckResourceAttr("data.profitbricks_lan.by_name", "ip_failover.#", "0"),
+					resource.TestCheckResourceAttr("data.profitbricks_lan.by_id", "name", "public"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceProfitBricksLan_matching = `
+
+data "profitbricks_lan" "by_name" {
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  name = "${profitbricks_lan.webserver_lan.name}"
+}
+
+data "profitbricks_lan" "by_id" {
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  id = "${profitbricks_lan.webserver_lan.id}"
+}`
@@ -3,8 +3,10 @@ package profitbricks
 import (
 	"fmt"
 	"log"
+	"net/http"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
 )
@@ -21,57 +23,219 @@ func dataSourceLocation() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"features": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"cpu_architecture": locationCPUArchitectureSchema(),
+			"locations": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"features": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"image_aliases": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"cpu_architecture": locationCPUArchitectureSchema(),
+					},
+				},
+			},
 		},
 		Timeouts: &resourceDefaultTimeouts,
 	}
 }
 
+func locationCPUArchitectureSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"cpu_family": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"max_cores": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"max_ram": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"vendor": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
 func dataSourceLocationRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*profitbricks.Client)
 
-	locations, err := client.ListLocations()
+	locations, err := listLocationsWithExtras(client)
 
 	if err != nil {
 		return fmt.Errorf("An error occured while fetching ProfitBricks locations %s", err)
 	}
 
 	name, nameOk := d.GetOk("name")
-	feature, featureOk := d.GetOk("features")
 
-	if !nameOk && !featureOk {
-		return fmt.Errorf("Either 'name' or 'feature' must be provided.")
+	// every feature given must be offered by a location
+	features := []string{}
+	if feature, ok := d.GetOk("feature"); ok {
+		features = append(features, feature.(string))
+	}
+	if featureSet, ok := d.GetOk("features"); ok {
+		for _, feature := range featureSet.(*schema.Set).List() {
+			features = append(features, feature.(string))
+		}
 	}
-	results := []profitbricks.Location{}
+
+	results := []locationWithExtras{}
 
 	for _, loc := range locations.Items {
-		if loc.Properties.Name == name.(string) || strings.Contains(loc.Properties.Name, name.(string)) {
-			results = append(results, loc)
+		if nameOk && loc.Properties.Name != name.(string) && !strings.Contains(loc.Properties.Name, name.(string)) {
+			continue
+		}
+		if !locationHasFeatures(loc, features) {
+			continue
 		}
+		results = append(results, loc)
 	}
 
-	if featureOk {
-		locationResults := []profitbricks.Location{}
-		for _, loc := range results {
-			for _, f := range loc.Properties.Features {
-				if f == feature.(string) {
-					locationResults = append(locationResults, loc)
-				}
-			}
-		}
-		results = locationResults
+	log.Printf("[INFO] Results length %d", len(results))
+
+	if len(results) == 0 {
+		return fmt.Errorf("There are no locations that match the search criteria")
+	}
+
+	locationsList := []map[string]interface{}{}
+	ids := []string{}
+	for _, loc := range results {
+		locationsList = append(locationsList, map[string]interface{}{
+			"id":               loc.ID,
+			"name":             loc.Properties.Name,
+			"features":         loc.Properties.Features,
+			"image_aliases":    loc.Properties.ImageAliases,
+			"cpu_architecture": flattenLocationCPUArchitecture(loc),
+		})
+		ids = append(ids, loc.ID)
+	}
+
+	if err := d.Set("locations", locationsList); err != nil {
+		return err
+	}
+
+	// only a search by name has to identify a single location, listing by features may return several
+	if !nameOk {
+		d.SetId(fmt.Sprintf("%d", hashcode.String(strings.Join(ids, ","))))
+		return nil
 	}
-	log.Printf("[INFO] Results length %d *************", len(results))
 
 	if len(results) > 1 {
 		log.Printf("[INFO] Results length greater than 1")
 		return fmt.Errorf("There is more than one location that match the search criteria")
 	}
 
-	if len(results) == 0 {
-		return fmt.Errorf("There are no locations that match the search criteria")
-	}
-
 	d.SetId(results[0].ID)
 
+	if err := d.Set("cpu_architecture", flattenLocationCPUArchitecture(results[0])); err != nil {
+		return err
+	}
+
 	return nil
 }
+
+func locationHasFeatures(location locationWithExtras, features []string) bool {
+	for _, feature := range features {
+		found := false
+		for _, f := range location.Properties.Features {
+			if f == feature {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func flattenLocationCPUArchitecture(location locationWithExtras) []map[string]interface{} {
+	cpuArchitectures := []map[string]interface{}{}
+	for _, cpuArchitecture := range location.Properties.CPUArchitecture {
+		cpuArchitectures = append(cpuArchitectures, map[string]interface{}{
+			"cpu_family": cpuArchitecture.CPUFamily,
+			"max_cores":  cpuArchitecture.MaxCores,
+			"max_ram":    cpuArchitecture.MaxRAM,
+			"vendor":     cpuArchitecture.Vendor,
+		})
+	}
+	return cpuArchitectures
+}
+
+// locationCPUArchitecture describes a cpu family offered in a location, which profitbricks-sdk-go does not model yet
+type locationCPUArchitecture struct {
+	CPUFamily string `json:"cpuFamily,omitempty"`
+	MaxCores  int    `json:"maxCores,omitempty"`
+	MaxRAM    int    `json:"maxRam,omitempty"`
+	Vendor    string `json:"vendor,omitempty"`
+}
+
+type locationWithExtras struct {
+	ID         string `json:"id,omitempty"`
+	Properties struct {
+		profitbricks.LocationProperties
+		CPUArchitecture []locationCPUArchitecture `json:"cpuArchitecture,omitempty"`
+	} `json:"properties"`
+}
+
+type locationsWithExtras struct {
+	Items []locationWithExtras `json:"items,omitempty"`
+}
+
+// listLocationsWithExtras lists all locations, once per provider
+func listLocationsWithExtras(client *profitbricks.Client) (*locationsWithExtras, error) {
+	locations, err := clientCache(client).get("locations", func() (interface{}, error) {
+		ret := &locationsWithExtras{}
+		// the depth of the client is replaced, the properties of the locations are all that is needed
+		req := client.R().SetResult(ret).SetQueryParam("depth", "1")
+		return ret, client.DoWithRequest(req, http.MethodGet, "/locations", http.StatusOK)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return locations.(*locationsWithExtras), nil
+}
+
+// getLocationWithExtras gets a location, once per provider
+func getLocationWithExtras(client *profitbricks.Client, locationId string) (*locationWithExtras, error) {
+	location, err := clientCache(client).get("location "+locationId, func() (interface{}, error) {
+		ret := &locationWithExtras{}
+		return ret, client.Get(fmt.Sprintf("/locations/%s", locationId), ret, http.StatusOK)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return location.(*locationWithExtras), nil
+}
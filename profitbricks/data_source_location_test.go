@@ -18,6 +18,15 @@ func TestAccDataSourceLocation_basic(t *testing.T) {
 				Config: testAccDataSourceProfitBricksLocation_basic,
 				Check: resource.ComposeTestCheckFunc(resource.TestCheckResourceAttr("data.profitbricks_location.loc", "id", "de/fkb"),
 					resource.TestCheckResourceAttr("data.profitbricks_location.loc", "name", "karlsruhe"),
+					resource.TestCheckResourceAttrSet("data.profitbricks_location.loc", "cpu_architecture.0.cpu_family"),
+				),
+			},
+			{
+				Config: testAccDataSourceProfitBricksLocation_features,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.profitbricks_location.ssd", "locations.0.id"),
+					resource.TestCheckResourceAttrSet("data.profitbricks_location.ssd", "locations.0.name"),
+					resource.TestCheckResourceAttrSet("data.profitbricks_location.ssd", "locations.0.cpu_architecture.0.cpu_family"),
 				),
 			},
 		},
@@ -31,3 +40,9 @@ const testAccDataSourceProfitBricksLocation_basic = `
 	  feature = "SSD"
 	}
 	`
+
+const testAccDataSourceProfitBricksLocation_features = `
+	data "profitbricks_location" "ssd" {
+	  features = ["SSD"]
+	}
+	`
@@ -0,0 +1,90 @@
+package profitbricks
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
+)
+
+func dataSourcePrivateCrossConnect() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePrivateCrossConnectRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"connectable_datacenters": privateCrossConnectConnectableDatacentersSchema(),
+			"peers":                   privateCrossConnectPeersSchema(),
+		},
+		Timeouts: &resourceDefaultTimeouts,
+	}
+}
+
+func dataSourcePrivateCrossConnectRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+
+	id, idOk := d.GetOk("id")
+	name, nameOk := d.GetOk("name")
+
+	if !idOk && !nameOk {
+		return fmt.Errorf("Either id or name has to be provided to look up a private cross-connect")
+	}
+
+	var pcc *profitbricks.PrivateCrossConnect
+
+	if idOk {
+		found, err := client.GetPrivateCrossConnect(id.(string))
+		if err != nil {
+			return fmt.Errorf("An error occured while fetching PCC %s: %s", id.(string), err)
+		}
+		if found.Properties == nil {
+			return fmt.Errorf("The API did not return the properties of PCC %s", id.(string))
+		}
+		if nameOk && found.Properties.Name != name.(string) {
+			return fmt.Errorf("PCC %s is named %s, not %s", id.(string), found.Properties.Name, name.(string))
+		}
+		pcc = found
+	} else {
+		pccs, err := client.ListPrivateCrossConnects()
+		if err != nil {
+			return fmt.Errorf("An error occured while fetching ProfitBricks PCCs %s", err)
+		}
+
+		results := []profitbricks.PrivateCrossConnect{}
+		for _, p := range pccs.Items {
+			if p.Properties != nil && p.Properties.Name == name.(string) {
+				results = append(results, p)
+			}
+		}
+
+		if len(results) > 1 {
+			return fmt.Errorf("There is more than one PCC named %s, please use its id instead", name.(string))
+		}
+
+		if len(results) == 0 {
+			return fmt.Errorf("There are no PCCs named %s", name.(string))
+		}
+
+		pcc = &results[0]
+	}
+
+	d.SetId(pcc.ID)
+	d.Set("name", pcc.Properties.Name)
+	d.Set("description", pcc.Properties.Description)
+	d.Set("peers", flattenPrivateCrossConnectPeers(pcc.Properties))
+	d.Set("connectable_datacenters", flattenPrivateCrossConnectConnectableDatacenters(pcc.Properties))
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package profitbricks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourcePrivateCrossConnect_matching(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+
+				Config: fmt.Sprintf(testAccCheckProfitBricksprivateCrossConnectConfigBasic, "datasource_test_pcc", "datasource test"),
+			},
+			{
+				Config: fmt.Sprintf(testAccDataSourceProfitBricksPrivateCrossConnect_matchingWithDataSource, "datasource_test_pcc", "datasource test"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.profitbricks_private_crossconnect.by_name", "id", "profitbricks_private_crossconnect.example", "id"),
+					resource.TestCheckResourceAttr("data.profitbricks_private_crossconnect.by_name", "description", "datasource test"),
+					resource.TestCheckResourceAttrPair("data.profitbricks_private_crossconnect.by_id", "name", "profitbricks_private_crossconnect.example", "name"),
+				),
+			},
+		},
+	})
+
+}
+
+const testAccDataSourceProfitBricksPrivateCrossConnect_matchingWithDataSource = testAccCheckProfitBricksprivateCrossConnectConfigBasic + `
+
+data "profitbricks_private_crossconnect" "by_name" {
+  name = "${profitbricks_private_crossconnect.example.name}"
+}
+
+data "profitbricks_private_crossconnect" "by_id" {
+  id = "${profitbricks_private_crossconnect.example.id}"
+}`
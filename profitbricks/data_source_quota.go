@@ -0,0 +1,94 @@
+package profitbricks
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
+)
+
+func dataSourceQuota() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"location": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "A location, e.g. de/fra, whose cpu architectures limit the size of servers further and whose snapshots are counted. The resource limits and their usage are totals of the contract either way",
+		},
+		"cpu_architecture": locationCPUArchitectureSchema(),
+		"snapshots_used": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+	}
+	// the resource limits are named like those of profitbricks_contract
+	for _, name := range contractResourceLimits {
+		s[name] = &schema.Schema{
+			Type:        schema.TypeInt,
+			Description: "A resource limit or usage of the whole contract, regardless of the location",
+			Computed:    true,
+		}
+	}
+
+	return &schema.Resource{
+		Read:     dataSourceQuotaRead,
+		Schema:   s,
+		Timeouts: &resourceDefaultTimeouts,
+	}
+}
+
+func dataSourceQuotaRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+
+	contract, err := client.GetContractResources()
+	if err != nil {
+		return fmt.Errorf("An error occured while fetching the contract resources %w", err)
+	}
+
+	limits := contract.Properties.ResourceLimits
+	if limits == nil {
+		return fmt.Errorf("The contract %d does not report any resource limits", contract.Properties.PBContractNumber)
+	}
+
+	// the API only reports the limits and their usage for the whole contract, not per location
+	for name, value := range flattenContractResourceLimits(limits)[0] {
+		d.Set(name, value)
+	}
+
+	location := d.Get("location").(string)
+
+	// there is no limit on snapshots, they are the only usage which can be counted per location
+	snapshotsUsed := 0
+	snapshots, err := client.ListSnapshots()
+	if err != nil {
+		return fmt.Errorf("An error occured while fetching the list of snapshots %w", err)
+	}
+	for _, snapshot := range snapshots.Items {
+		if location == "" || snapshot.Properties.Location == location {
+			snapshotsUsed++
+		}
+	}
+	d.Set("snapshots_used", snapshotsUsed)
+
+	cpuArchitectures := []map[string]interface{}{}
+	if location != "" {
+		loc, err := getLocationWithExtras(client, location)
+		if err != nil {
+			return fmt.Errorf("An error occured while fetching the location %s %w", location, err)
+		}
+		cpuArchitectures = flattenLocationCPUArchitecture(*loc)
+	}
+	if err := d.Set("cpu_architecture", cpuArchitectures); err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Contract %d uses %d of %d cores", contract.Properties.PBContractNumber, limits.CoresProvisioned, limits.CoresPerContract)
+
+	id := fmt.Sprintf("%d", contract.Properties.PBContractNumber)
+	if location != "" {
+		id = fmt.Sprintf("%s/%s", id, location)
+	}
+	d.SetId(id)
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package profitbricks
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceQuota_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceProfitBricksQuota_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.profitbricks_quota.contract", "cores_per_contract"),
+					resource.TestCheckResourceAttrSet("data.profitbricks_quota.contract", "cores_provisioned"),
+					resource.TestCheckResourceAttrSet("data.profitbricks_quota.contract", "ram_per_server"),
+					resource.TestCheckResourceAttr("data.profitbricks_quota.contract", "cpu_architecture.#", "0"),
+					resource.TestCheckResourceAttrSet("data.profitbricks_quota.fra", "cpu_architecture.0.max_cores"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceProfitBricksQuota_basic = `
+data "profitbricks_quota" "contract" {
+}
+
+data "profitbricks_quota" "fra" {
+  location = "de/fra"
+}
+`
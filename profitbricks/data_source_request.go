@@ -0,0 +1,128 @@
+package profitbricks
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
+)
+
+func dataSourceRequest() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRequestRead,
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Description: "The status url of the request as returned in the Location header, or the request id",
+				Required:    true,
+			},
+			"wait": {
+				Type:        schema.TypeBool,
+				Description: "Whether to wait until the request is finished",
+				Optional:    true,
+				Default:     false,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"message": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"targets": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+		Timeouts: &resourceDefaultTimeouts,
+	}
+}
+
+func dataSourceRequestRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+	path := requestStatusPath(d.Get("path").(string))
+
+	getRequest := func() (interface{}, string, error) {
+		var request *profitbricks.RequestStatus
+		err := getWithRetries(client, func() (err error) {
+			request, err = client.GetRequestStatus(path)
+			return err
+		})
+		if err != nil {
+			return nil, "", fmt.Errorf("An error occured while fetching the status of request %s: %s", path, err)
+		}
+		return request, request.Metadata.Status, nil
+	}
+
+	var result interface{}
+	var err error
+	if d.Get("wait").(bool) {
+		log.Printf("[INFO] Waiting for request %s to finish", path)
+		// a failed request is finished as well, its message tells why
+		stateConf := &resource.StateChangeConf{
+			Pending:    resourcePendingStates,
+			Target:     []string{"DONE", "FAILED"},
+			Refresh:    getRequest,
+			Timeout:    d.Timeout(schema.TimeoutRead),
+			MinTimeout: pollInterval(client, 10*time.Second),
+		}
+		result, err = stateConf.WaitForState()
+	} else {
+		result, _, err = getRequest()
+	}
+	if err != nil {
+		return err
+	}
+
+	request := result.(*profitbricks.RequestStatus)
+
+	targets := []map[string]interface{}{}
+	for _, target := range request.Metadata.Targets {
+		targets = append(targets, map[string]interface{}{
+			"id":     target.Target.ID,
+			"type":   target.Target.PBType,
+			"status": target.Status,
+		})
+	}
+
+	d.SetId(path)
+	d.Set("status", request.Metadata.Status)
+	d.Set("message", request.Metadata.Message)
+	if err := d.Set("targets", targets); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// requestStatusPath turns a request id into the path of its status, urls and paths are kept
+func requestStatusPath(path string) string {
+	if IsValidUUID(path) {
+		return fmt.Sprintf("/requests/%s/status", path)
+	}
+	if !strings.HasPrefix(path, "/") && !strings.Contains(path, "://") {
+		return "/" + path
+	}
+	return path
+}
@@ -0,0 +1,60 @@
+package profitbricks
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataSourceRequest_wait(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if calls == 1 {
+			w.Write([]byte(`{"metadata": {"status": "RUNNING"}}`))
+			return
+		}
+		w.Write([]byte(`{"metadata": {"status": "DONE", "message": "Request has been successfully executed", "targets": [{"target": {"id": "server-id", "type": "server"}, "status": "DONE"}]}}`))
+	}))
+	defer server.Close()
+
+	config := Config{Token: "token", Endpoint: server.URL, PollInterval: 1}
+	client, err := config.Client("0.12")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceRequest().Schema, map[string]interface{}{
+		"path": server.URL + "/requests/1/status",
+		"wait": true,
+	})
+	if err := dataSourceRequestRead(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", calls)
+	}
+	if status := d.Get("status").(string); status != "DONE" {
+		t.Fatalf("expected status DONE, got %q", status)
+	}
+	if id := d.Get("targets.0.id").(string); id != "server-id" {
+		t.Fatalf("expected target server-id, got %q", id)
+	}
+}
+
+func TestDataSourceRequest_path(t *testing.T) {
+	for path, expected := range map[string]string{
+		"15f67991-0f51-4efc-a8ad-ef1fb31a480c":                       "/requests/15f67991-0f51-4efc-a8ad-ef1fb31a480c/status",
+		"requests/1/status":                                          "/requests/1/status",
+		"/requests/1/status":                                         "/requests/1/status",
+		"https://api.profitbricks.com/cloudapi/v5/requests/1/status": "https://api.profitbricks.com/cloudapi/v5/requests/1/status",
+	} {
+		if actual := requestStatusPath(path); actual != expected {
+			t.Errorf("expected %q for %q, got %q", expected, path, actual)
+		}
+	}
+}
@@ -2,7 +2,12 @@ package profitbricks
 
 import (
 	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
 )
@@ -19,11 +24,118 @@ func dataSourceResource() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"depth": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1,
+				Description: "The level of detail the API returns resources with, higher levels are slower on large contracts.",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if v.(int) < 0 || v.(int) > 10 {
+						errors = append(errors, fmt.Errorf("%q must be between 0 and 10, got %d", k, v.(int)))
+					}
+					return
+				},
+			},
+			"limit": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The number of resources fetched per request, all pages are fetched. 0 fetches all resources with a single request.",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if v.(int) < 0 {
+						errors = append(errors, fmt.Errorf("%q must not be negative, got %d", k, v.(int)))
+					}
+					return
+				},
+			},
+			"offset": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The number of resources to skip.",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if v.(int) < 0 {
+						errors = append(errors, fmt.Errorf("%q must not be negative, got %d", k, v.(int)))
+					}
+					return
+				},
+			},
+			"resources": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 		Timeouts: &resourceDefaultTimeouts,
 	}
 }
 
+// resourcesPage is a page of resources, with the link to the next page if there is one
+type resourcesPage struct {
+	Items []profitbricks.Resource `json:"items,omitempty"`
+	Links struct {
+		Next string `json:"next,omitempty"`
+	} `json:"_links,omitempty"`
+}
+
+// listResourcesWithPaging lists the resources, of resourceType if it is set, following the pages of the collection.
+// depth overrides the depth of the client for these requests
+func listResourcesWithPaging(client *profitbricks.Client, resourceType string, depth int, offset int, limit int) ([]profitbricks.Resource, error) {
+	path := "/um/resources"
+	if resourceType != "" {
+		path = fmt.Sprintf("/um/resources/%s", resourceType)
+	}
+
+	items := []profitbricks.Resource{}
+	seen := map[string]bool{}
+	for path != "" {
+		page := &resourcesPage{}
+		req := client.R().SetResult(page).SetQueryParam("depth", strconv.Itoa(depth))
+		if limit > 0 && !strings.Contains(path, "?") {
+			req.SetQueryParam("offset", strconv.Itoa(offset))
+			req.SetQueryParam("limit", strconv.Itoa(limit))
+		}
+		if err := client.DoWithRequest(req, http.MethodGet, path, http.StatusOK); err != nil {
+			return nil, err
+		}
+
+		added := 0
+		for _, item := range page.Items {
+			if !seen[item.ID] {
+				seen[item.ID] = true
+				items = append(items, item)
+				added++
+			}
+		}
+		log.Printf("[INFO] Fetched %d resources from %s", added, path)
+
+		switch {
+		case page.Links.Next != "":
+			path = page.Links.Next
+		case limit > 0 && len(page.Items) == limit && added > 0:
+			// no links, but a full page: ask for the next one until a page is short or repeats
+			offset += limit
+		default:
+			path = ""
+		}
+	}
+
+	return items, nil
+}
+
 func dataSourceResourceRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*profitbricks.Client)
 
@@ -35,37 +147,40 @@ func dataSourceResourceRead(d *schema.ResourceData, meta interface{}) error {
 	if resource_type != "" && resource_id != "" {
 		result, err := client.GetResourceByType(resource_type, resource_id)
 		if err != nil {
-			return fmt.Errorf("An error occured while fetching resource by type %s", err)
+			return fmt.Errorf("An error occured while fetching resource by type %w", err)
 		}
 		results = append(results, *result)
 
 		d.Set("resource_type", result.PBType)
 		d.Set("resource_id", result.ID)
-	} else if resource_type != "" {
-		items, err := client.ListResourcesByType(resource_type)
-		if err != nil {
-			return fmt.Errorf("An error occured while fetching resources by type %s", err)
-		}
-
-		results = items.Items
-		d.Set("resource_type", results[0].PBType)
 	} else {
-		items, err := client.ListResources()
+		items, err := listResourcesWithPaging(client, resource_type, d.Get("depth").(int), d.Get("offset").(int), d.Get("limit").(int))
 		if err != nil {
-			return fmt.Errorf("An error occured while fetching resources %s", err)
+			return fmt.Errorf("An error occured while fetching resources %w", err)
 		}
-		results = items.Items
+		results = items
 	}
 
-	if len(results) > 1 {
-		return fmt.Errorf("There is more than one resource that match the search criteria")
+	resources := []map[string]interface{}{}
+	ids := []string{}
+	for _, result := range results {
+		resources = append(resources, map[string]interface{}{
+			"id":   result.ID,
+			"type": result.PBType,
+			"href": result.Href,
+		})
+		ids = append(ids, result.ID)
 	}
-
-	if len(results) == 0 {
-		return fmt.Errorf("There are no resources that match the search criteria")
+	if err := d.Set("resources", resources); err != nil {
+		return err
 	}
 
-	d.SetId(results[0].ID)
+	// a single resource keeps being the id of the data source, as it was before listing was supported
+	if len(results) == 1 {
+		d.SetId(results[0].ID)
+	} else {
+		d.SetId(fmt.Sprintf("%d", hashcode.String(strings.Join(ids, ","))))
+	}
 
 	return nil
 }
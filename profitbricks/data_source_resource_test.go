@@ -1,9 +1,13 @@
 package profitbricks
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
 func TestAccResource_basic(t *testing.T) {
@@ -19,6 +23,12 @@ func TestAccResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("data.profitbricks_resource.res", "resource_type", "datacenter"),
 				),
 			},
+			{
+				Config: testAccDataSourceProfitBricksResource_list,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.profitbricks_resource.datacenters", "resources.0.type", "datacenter"),
+				),
+			},
 		},
 	})
 
@@ -34,3 +44,62 @@ data "profitbricks_resource" "res" {
   resource_type = "datacenter"
   resource_id="${profitbricks_datacenter.foobar.id}"
 }`
+
+const testAccDataSourceProfitBricksResource_list = `
+resource "profitbricks_datacenter" "foobar" {
+  name       = "test_name"
+  location = "us/las"
+}
+
+data "profitbricks_resource" "datacenters" {
+  resource_type = "datacenter"
+  limit = 10
+  depends_on = ["profitbricks_datacenter.foobar"]
+}`
+
+func TestDataSourceResource_paging(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("offset") {
+		case "0":
+			w.Write([]byte(`{"items": [{"id": "1", "type": "server"}, {"id": "2", "type": "server"}]}`))
+		case "2":
+			w.Write([]byte(`{"items": [{"id": "3", "type": "server"}, {"id": "4", "type": "server"}], "_links": {"next": "` + "http://" + r.Host + `/um/resources/server?cursor=next"}}`))
+		default:
+			w.Write([]byte(`{"items": [{"id": "5", "type": "server"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	config := Config{Token: "token", Endpoint: server.URL}
+	client, err := config.Client("0.12")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	client.SetDepth(5)
+
+	d := schema.TestResourceDataRaw(t, dataSourceResource().Schema, map[string]interface{}{
+		"resource_type": "server",
+		"limit":         2,
+	})
+	if err := dataSourceResourceRead(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if count := d.Get("resources.#").(int); count != 5 {
+		t.Fatalf("expected 5 resources, got %d", count)
+	}
+	if id := d.Get("resources.4.id").(string); id != "5" {
+		t.Fatalf("expected the last resource to be 5, got %q", id)
+	}
+	if len(queries) != 3 {
+		t.Fatalf("expected 3 requests, got %d: %v", len(queries), queries)
+	}
+	for _, query := range queries {
+		if depth := strings.Count(query, "depth="); depth != 1 || !strings.Contains(query, "depth=1") {
+			t.Fatalf("expected the depth of the data source to replace the depth of the client, got %q", query)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package profitbricks
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
+)
+
+func dataSourceServer() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceServerRead,
+		Schema: map[string]*schema.Schema{
+			"datacenter_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"cores": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"ram": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"cpu_family": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"availability_zone": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vm_state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"boot_volume": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"boot_cdrom": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"primary_nic": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"primary_ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"nics": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"lan": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"mac": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ips": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"dhcp": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"nat": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"firewall_active": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+		Timeouts: &resourceDefaultTimeouts,
+	}
+}
+
+func dataSourceServerRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+
+	dcId := d.Get("datacenter_id").(string)
+	if dcId == "" {
+		return fmt.Errorf("'datacenter_id' must not be empty, a server is looked up in a data center")
+	}
+
+	id, idOk := d.GetOk("id")
+	name, nameOk := d.GetOk("name")
+	if !idOk && !nameOk {
+		return fmt.Errorf("either id or name must be set")
+	}
+
+	var server *profitbricks.Server
+	if idOk {
+		found, err := client.GetServer(dcId, id.(string))
+		if err != nil {
+			return fmt.Errorf("An error occured while fetching the server %s in data center %s %w", id.(string), dcId, err)
+		}
+		if nameOk && found.Properties.Name != name.(string) {
+			return fmt.Errorf("The name of the server %s is %s, not %s", found.ID, found.Properties.Name, name.(string))
+		}
+		server = found
+	} else {
+		servers, err := client.ListServers(dcId)
+		if err != nil {
+			return fmt.Errorf("An error occured while fetching the servers of data center %s %w", dcId, err)
+		}
+
+		results := []profitbricks.Server{}
+		for _, s := range servers.Items {
+			if s.Properties.Name == name.(string) {
+				results = append(results, s)
+			}
+		}
+
+		if len(results) > 1 {
+			return fmt.Errorf("There is more than one server named %s in data center %s", name.(string), dcId)
+		}
+		if len(results) == 0 {
+			return fmt.Errorf("There are no servers named %s in data center %s", name.(string), dcId)
+		}
+		server = &results[0]
+	}
+
+	log.Printf("[INFO] Got server %s (%s)", server.Properties.Name, server.ID)
+
+	d.SetId(server.ID)
+	d.Set("name", server.Properties.Name)
+	d.Set("cores", server.Properties.Cores)
+	d.Set("ram", server.Properties.RAM)
+	d.Set("cpu_family", server.Properties.CPUFamily)
+	d.Set("availability_zone", server.Properties.AvailabilityZone)
+	d.Set("vm_state", server.Properties.VMState)
+
+	bootVolume, bootCdrom := "", ""
+	if server.Properties.BootVolume != nil {
+		bootVolume = server.Properties.BootVolume.ID
+	}
+	if server.Properties.BootCdrom != nil {
+		bootCdrom = server.Properties.BootCdrom.ID
+	}
+	d.Set("boot_volume", bootVolume)
+	d.Set("boot_cdrom", bootCdrom)
+
+	nics := []map[string]interface{}{}
+	if server.Entities != nil && server.Entities.Nics != nil {
+		for _, nic := range server.Entities.Nics.Items {
+			if nic.Properties == nil {
+				continue
+			}
+			n := map[string]interface{}{
+				"id":   nic.ID,
+				"name": nic.Properties.Name,
+				"lan":  nic.Properties.Lan,
+				"mac":  nic.Properties.Mac,
+				"ips":  nic.Properties.Ips,
+			}
+			if nic.Properties.Dhcp != nil {
+				n["dhcp"] = *nic.Properties.Dhcp
+			}
+			if nic.Properties.Nat != nil {
+				n["nat"] = *nic.Properties.Nat
+			}
+			if nic.Properties.FirewallActive != nil {
+				n["firewall_active"] = *nic.Properties.FirewallActive
+			}
+			nics = append(nics, n)
+		}
+	}
+	if err := d.Set("nics", nics); err != nil {
+		return err
+	}
+
+	primaryNic, primaryIp := "", ""
+	if len(nics) > 0 {
+		primaryNic = nics[0]["id"].(string)
+		if ips := nics[0]["ips"].([]string); len(ips) > 0 {
+			primaryIp = ips[0]
+		}
+	}
+	d.Set("primary_nic", primaryNic)
+	d.Set("primary_ip", primaryIp)
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package profitbricks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceServer_matching(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckProfitbricksServerConfig_basic, "webserver"),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckProfitbricksServerConfig_basic, "webserver") + testAccDataSourceProfitBricksServer_matching,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.profitbricks_server.by_name", "id", "profitbricks_server.webserver", "id"),
+					resource.TestCheckResourceAttr("data.profitbricks_server.by_name", "cores", "1"),
+					resource.TestCheckResourceAttr("data.profitbricks_server.by_name", "ram", "1024"),
+					resource.TestCheckResourceAttr("data.profitbricks_server.by_name", "cpu_family", "AMD_OPTERON"),
+					resource.TestCheckResourceAttrPair("data.profitbricks_server.by_name", "boot_volume", "profitbricks_server.webserver", "boot_volume"),
+					resource.TestCheckResourceAttrPair("data.profitbricks_server.by_name", "primary_ip", "profitbricks_server.webserver", "primary_ip"),
+					resource.TestCheckResourceAttr("data.profitbricks_server.by_name", "nics.#", "1"),
+					resource.TestCheckResourceAttrPair("data.profitbricks_server.by_id", "name", "profitbricks_server.webserver", "name"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceProfitBricksServer_matching = `
+
+data "profitbricks_server" "by_name" {
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  name = "${profitbricks_server.webserver.name}"
+}
+
+data "profitbricks_server" "by_id" {
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  id = "${profitbricks_server.webserver.id}"
+}`
@@ -0,0 +1,54 @@
+package profitbricks
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
+)
+
+func dataSourceShare() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceShareRead,
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"resource_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"edit_privilege": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"share_privilege": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+		Timeouts: &resourceDefaultTimeouts,
+	}
+}
+
+func dataSourceShareRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+	groupId := d.Get("group_id").(string)
+	resourceId := d.Get("resource_id").(string)
+
+	share, err := client.GetShare(groupId, resourceId)
+
+	if err != nil {
+		if apiError, ok := err.(profitbricks.ApiError); ok && apiError.HttpStatusCode() == 404 {
+			return fmt.Errorf("Resource %s is not shared with group %s", resourceId, groupId)
+		}
+		return fmt.Errorf("An error occured while fetching the share of resource %s with group %s: %s", resourceId, groupId, err)
+	}
+
+	d.SetId(share.ID)
+	d.Set("edit_privilege", share.Properties.EditPrivilege)
+	d.Set("share_privilege", share.Properties.SharePrivilege)
+
+	return nil
+}
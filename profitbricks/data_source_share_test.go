@@ -0,0 +1,37 @@
+package profitbricks
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceShare_matching(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+
+				Config: testAccCheckProfitbricksShareConfig_basic,
+			},
+			{
+				Config: testAccDataSourceProfitBricksShare_matchingWithDataSource,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.profitbricks_share.share", "edit_privilege", "true"),
+					resource.TestCheckResourceAttr("data.profitbricks_share.share", "share_privilege", "true"),
+				),
+			},
+		},
+	})
+
+}
+
+const testAccDataSourceProfitBricksShare_matchingWithDataSource = testAccCheckProfitbricksShareConfig_basic + `
+
+data "profitbricks_share" "share" {
+  group_id = "${profitbricks_share.share.group_id}"
+  resource_id = "${profitbricks_share.share.resource_id}"
+}`
@@ -0,0 +1,89 @@
+package profitbricks
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
+)
+
+func dataSourceUser() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceUserRead,
+		Schema: map[string]*schema.Schema{
+			"email": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"first_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"administrator": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"force_sec_auth": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"groups": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+		},
+		Timeouts: &resourceDefaultTimeouts,
+	}
+}
+
+func dataSourceUserRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+
+	users, err := client.ListUsers()
+
+	if err != nil {
+		return fmt.Errorf("An error occured while fetching ProfitBricks users %s", err)
+	}
+
+	// emails are stored lowercased, so they are matched regardless of their case
+	email := d.Get("email").(string)
+	var user *profitbricks.User
+
+	for i, u := range users.Items {
+		if u.Properties != nil && strings.EqualFold(u.Properties.Email, email) {
+			user = &users.Items[i]
+			break
+		}
+	}
+
+	if user == nil {
+		return fmt.Errorf("There are no users with email %s", email)
+	}
+
+	d.SetId(user.ID)
+	d.Set("email", user.Properties.Email)
+	d.Set("first_name", user.Properties.Firstname)
+	d.Set("last_name", user.Properties.Lastname)
+	d.Set("administrator", user.Properties.Administrator)
+	d.Set("force_sec_auth", user.Properties.ForceSecAuth)
+
+	groups := &profitbricks.Groups{}
+	if err := client.Get(fmt.Sprintf("/um/users/%s/groups", user.ID), groups, http.StatusOK); err != nil {
+		return fmt.Errorf("An error occured while fetching the groups of user %s: %s", user.ID, err)
+	}
+
+	groupIds := []string{}
+	for _, group := range groups.Items {
+		groupIds = append(groupIds, group.ID)
+	}
+	d.Set("groups", groupIds)
+
+	return nil
+}
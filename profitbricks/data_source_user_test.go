@@ -0,0 +1,65 @@
+package profitbricks
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceUser_matching(t *testing.T) {
+	r1 := rand.New(rand.NewSource(time.Now().UnixNano()))
+	email := strconv.Itoa(r1.Intn(100000)) + "terraform_test" + strconv.Itoa(r1.Intn(100000)) + "@go.com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+
+				Config: fmt.Sprintf(testAccDataSourceProfitBricksUser_matching, email),
+			},
+			{
+				Config: fmt.Sprintf(testAccDataSourceProfitBricksUser_matchingWithDataSource, email, strings.ToUpper(email)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.profitbricks_user.foobar", "id", "profitbricks_user.foobar", "id"),
+					resource.TestCheckResourceAttr("data.profitbricks_user.foobar", "email", email),
+					resource.TestCheckResourceAttr("data.profitbricks_user.foobar", "first_name", "terraform"),
+					resource.TestCheckResourceAttr("data.profitbricks_user.foobar", "administrator", "false"),
+				),
+			},
+		},
+	})
+
+}
+
+const testAccDataSourceProfitBricksUser_matching = `
+resource "profitbricks_user" "foobar" {
+  first_name = "terraform"
+  last_name = "test"
+  email = "%s"
+  password = "abc123-321CBA"
+  administrator = false
+  force_sec_auth= false
+}
+`
+
+const testAccDataSourceProfitBricksUser_matchingWithDataSource = `
+resource "profitbricks_user" "foobar" {
+  first_name = "terraform"
+  last_name = "test"
+  email = "%s"
+  password = "abc123-321CBA"
+  administrator = false
+  force_sec_auth= false
+}
+
+data "profitbricks_user" "foobar" {
+  email = "%s"
+}`
@@ -0,0 +1,143 @@
+package profitbricks
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
+)
+
+func dataSourceVolume() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceVolumeRead,
+		Schema: map[string]*schema.Schema{
+			"datacenter_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"disk_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"licence_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"availability_zone": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bus": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"image": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"device_number": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"server_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+		Timeouts: &resourceDefaultTimeouts,
+	}
+}
+
+func dataSourceVolumeRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+
+	dcId := d.Get("datacenter_id").(string)
+	if dcId == "" {
+		return fmt.Errorf("'datacenter_id' must not be empty, a volume is looked up in a data center")
+	}
+
+	id, idOk := d.GetOk("id")
+	name, nameOk := d.GetOk("name")
+	if !idOk && !nameOk {
+		return fmt.Errorf("either id or name must be set")
+	}
+
+	var volume *profitbricks.Volume
+	if idOk {
+		found, err := client.GetVolume(dcId, id.(string))
+		if err != nil {
+			return fmt.Errorf("An error occured while fetching the volume %s in data center %s %w", id.(string), dcId, err)
+		}
+		if nameOk && found.Properties.Name != name.(string) {
+			return fmt.Errorf("The name of the volume %s is %s, not %s", found.ID, found.Properties.Name, name.(string))
+		}
+		volume = found
+	} else {
+		volumes, err := client.ListVolumes(dcId)
+		if err != nil {
+			return fmt.Errorf("An error occured while fetching the volumes of data center %s %w", dcId, err)
+		}
+
+		results := []profitbricks.Volume{}
+		for _, v := range volumes.Items {
+			if v.Properties.Name == name.(string) {
+				results = append(results, v)
+			}
+		}
+
+		if len(results) > 1 {
+			return fmt.Errorf("There is more than one volume named %s in data center %s", name.(string), dcId)
+		}
+		if len(results) == 0 {
+			return fmt.Errorf("There are no volumes named %s in data center %s", name.(string), dcId)
+		}
+		volume = &results[0]
+	}
+
+	log.Printf("[INFO] Got volume %s (%s)", volume.Properties.Name, volume.ID)
+
+	// volumes do not know the server they are attached to, the servers of the data center list their volumes
+	servers, err := client.ListServers(dcId)
+	if err != nil {
+		return fmt.Errorf("An error occured while fetching the servers of data center %s %w", dcId, err)
+	}
+	serverId := ""
+	for _, server := range servers.Items {
+		if server.Entities == nil || server.Entities.Volumes == nil {
+			continue
+		}
+		for _, attached := range server.Entities.Volumes.Items {
+			if attached.ID == volume.ID {
+				serverId = server.ID
+			}
+		}
+	}
+
+	d.SetId(volume.ID)
+	d.Set("name", volume.Properties.Name)
+	d.Set("size", volume.Properties.Size)
+	d.Set("disk_type", volume.Properties.Type)
+	d.Set("licence_type", volume.Properties.LicenceType)
+	d.Set("availability_zone", volume.Properties.AvailabilityZone)
+	d.Set("bus", volume.Properties.Bus)
+	d.Set("image", volume.Properties.Image)
+	d.Set("device_number", volume.Properties.DeviceNumber)
+	d.Set("server_id", serverId)
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package profitbricks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceVolume_matching(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckProfitbricksVolumeConfig_basic, "data"),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckProfitbricksVolumeConfig_basic, "data") + testAccDataSourceProfitBricksVolume_matching,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.profitbricks_volume.by_name", "id", "profitbricks_volume.database_volume", "id"),
+					resource.TestCheckResourceAttr("data.profitbricks_volume.by_name", "size", "5"),
+					resource.TestCheckResourceAttr("data.profitbricks_volume.by_name", "disk_type", "HDD"),
+					resource.TestCheckResourceAttr("data.profitbricks_volume.by_name", "licence_type", "OTHER"),
+					resource.TestCheckResourceAttr("data.profitbricks_volume.by_name", "bus", "VIRTIO"),
+					resource.TestCheckResourceAttrPair("data.profitbricks_volume.by_name", "server_id", "profitbricks_server.webserver", "id"),
+					resource.TestCheckResourceAttr("data.profitbricks_volume.by_id", "name", "data"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceProfitBricksVolume_matching = `
+
+data "profitbricks_volume" "by_name" {
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  name = "${profitbricks_volume.database_volume.name}"
+}
+
+data "profitbricks_volume" "by_id" {
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  id = "${profitbricks_volume.database_volume.id}"
+}`
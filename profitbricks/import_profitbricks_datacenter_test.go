@@ -1,10 +1,15 @@
 package profitbricks
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
 )
 
 func TestAccProfitBricksDataCenter_ImportBasic(t *testing.T) {
@@ -27,3 +32,82 @@ func TestAccProfitBricksDataCenter_ImportBasic(t *testing.T) {
 		},
 	})
 }
+
+func TestAccProfitBricksDataCenter_ImportExisting(t *testing.T) {
+	var datacenterID string
+	datacenterName := "datacenter-importexisting"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					client := testAccProfitBricksClient(t)
+					dc, err := client.CreateDatacenterAndWait(context.TODO(), profitbricks.Datacenter{
+						Properties: profitbricks.DatacenterProperties{
+							Name:        datacenterName,
+							Location:    "us/las",
+							Description: "created out of band",
+						},
+					})
+					if err != nil {
+						t.Fatalf("Error creating datacenter out of band: %s", err)
+					}
+					datacenterID = dc.ID
+				},
+				Config:       fmt.Sprintf(testAccCheckProfitBricksDatacenterConfig_basic, datacenterName),
+				ResourceName: "profitbricks_datacenter.foobar",
+				ImportState:  true,
+				ImportStateIdFunc: func(*terraform.State) (string, error) {
+					return datacenterID, nil
+				},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					defer testAccProfitBricksDeleteDatacenter(t, datacenterID)
+
+					if len(states) != 1 {
+						return fmt.Errorf("Expected 1 imported datacenter, got %d", len(states))
+					}
+					attributes := states[0].Attributes
+					if attributes["name"] != datacenterName {
+						return fmt.Errorf("Expected name %q, got %q", datacenterName, attributes["name"])
+					}
+					if attributes["location"] != "us/las" {
+						return fmt.Errorf("Expected location %q, got %q", "us/las", attributes["location"])
+					}
+					if attributes["description"] != "created out of band" {
+						return fmt.Errorf("Expected description %q, got %q", "created out of band", attributes["description"])
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+// testAccProfitBricksClient builds a client from the acceptance test environment, for resources created out of band
+func testAccProfitBricksClient(t *testing.T) *profitbricks.Client {
+	config := Config{
+		Username: os.Getenv("PROFITBRICKS_USERNAME"),
+		Password: os.Getenv("PROFITBRICKS_PASSWORD"),
+		Token:    os.Getenv("PROFITBRICKS_TOKEN"),
+		Endpoint: cleanURL(os.Getenv("PROFITBRICKS_API_URL")),
+	}
+
+	client, err := config.Client("acceptance-test")
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+	return client
+}
+
+func testAccProfitBricksDeleteDatacenter(t *testing.T, datacenterID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	if err := testAccProfitBricksClient(t).DeleteDatacenterAndWait(ctx, datacenterID); err != nil {
+		t.Errorf("Error deleting datacenter %s created out of band: %s", datacenterID, err)
+	}
+}
@@ -2,7 +2,7 @@ package profitbricks
 
 import (
 	"fmt"
-
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
@@ -27,6 +27,12 @@ func TestAccProfitBricksFirewall_ImportBasic(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:  "profitbricks_firewall.webserver_http",
+				ImportStateId: "datacenter/server/nic",
+				ImportState:   true,
+				ExpectError:   regexp.MustCompile("got 3 segments instead of 4"),
+			},
 		},
 	})
 }
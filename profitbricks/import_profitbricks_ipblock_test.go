@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
 )
 
 func TestAccProfitBricksIPBlock_ImportBasic(t *testing.T) {
@@ -28,3 +30,79 @@ func TestAccProfitBricksIPBlock_ImportBasic(t *testing.T) {
 		},
 	})
 }
+
+func TestAccProfitBricksIPBlock_ImportOutOfBand(t *testing.T) {
+	var ipBlockId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			// the out of band ip block is not part of the state, so it is released here
+			if ipBlockId != "" {
+				client := testAccProvider.Meta().(*profitbricks.Client)
+				if _, err := client.ReleaseIPBlock(ipBlockId); err != nil {
+					return fmt.Errorf("Error releasing IP block %s created out of band: %s", ipBlockId, err)
+				}
+			}
+			return testAccCheckDProfitBricksIPBlockDestroyCheck(s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckProfitbricksIPBlockConfig_outOfBandLocation,
+				Check:  testAccCreateProfitBricksIPBlockOutOfBand(&ipBlockId),
+			},
+			{
+				Config:       testAccCheckProfitbricksIPBlockConfig_outOfBand,
+				ResourceName: "profitbricks_ipblock.imported",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return ipBlockId, nil
+				},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("Expected one imported IP block, got %d", len(states))
+					}
+					attrs := states[0].Attributes
+					if attrs["name"] != "out-of-band" || attrs["location"] != "us/las" || attrs["size"] != "1" || attrs["ips.#"] != "1" {
+						return fmt.Errorf("Unexpected attributes of imported IP block: %+v", attrs)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+// testAccCreateProfitBricksIPBlockOutOfBand reserves an ip block through the API, bypassing terraform
+func testAccCreateProfitBricksIPBlockOutOfBand(ipBlockId *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*profitbricks.Client)
+
+		ipblock, err := client.ReserveIPBlock(profitbricks.IPBlock{
+			Properties: profitbricks.IPBlockProperties{
+				Name:     "out-of-band",
+				Location: "us/las",
+				Size:     1,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("Error reserving IP block out of band: %s", err)
+		}
+
+		*ipBlockId = ipblock.ID
+		return client.WaitTillProvisioned(ipblock.Headers.Get("Location"))
+	}
+}
+
+const testAccCheckProfitbricksIPBlockConfig_outOfBandLocation = `
+data "profitbricks_location" "las" {
+  name = "lasvegas"
+}`
+
+const testAccCheckProfitbricksIPBlockConfig_outOfBand = `
+resource "profitbricks_ipblock" "imported" {
+  location = "us/las"
+  size = 1
+  name = "out-of-band"
+}`
@@ -0,0 +1,42 @@
+package profitbricks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccProfitBricksLanIPFailover_ImportBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksLanIPFailoverDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckProfitbricksLanIPFailoverConfig_basic,
+			},
+			{
+				ResourceName:      "profitbricks_ipfailover.failovertest",
+				ImportStateIdFunc: testAccProfitBricksLanIPFailoverImportStateId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccProfitBricksLanIPFailoverImportStateId(s *terraform.State) (string, error) {
+	var importID string = ""
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "profitbricks_ipfailover" {
+			continue
+		}
+
+		importID = fmt.Sprintf("%s/%s", rs.Primary.Attributes["datacenter_id"], rs.Primary.Attributes["lan_id"])
+	}
+
+	return importID, nil
+}
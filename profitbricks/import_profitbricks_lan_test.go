@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
 )
 
 func TestAccProfitBricksLan_ImportBasic(t *testing.T) {
@@ -31,6 +32,69 @@ func TestAccProfitBricksLan_ImportBasic(t *testing.T) {
 	})
 }
 
+func TestAccProfitBricksLan_ImportOutOfBand(t *testing.T) {
+	var dcId, lanId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksLanDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckProfitbricksLanConfig_outOfBandDatacenter,
+				Check:  testAccCreateProfitBricksLanOutOfBand("profitbricks_datacenter.foobar", &dcId, &lanId),
+			},
+			{
+				Config:       testAccCheckProfitbricksLanConfig_outOfBand,
+				ResourceName: "profitbricks_lan.imported",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return fmt.Sprintf("%s/%s", dcId, lanId), nil
+				},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("Expected one imported LAN, got %d", len(states))
+					}
+					attrs := states[0].Attributes
+					if attrs["datacenter_id"] != dcId || attrs["name"] != "out-of-band" || attrs["public"] != "true" {
+						return fmt.Errorf("Unexpected attributes of imported LAN: %+v", attrs)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+// testAccCreateProfitBricksLanOutOfBand creates a LAN in the data center through the API, bypassing terraform
+func testAccCreateProfitBricksLanOutOfBand(n string, dcId, lanId *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*profitbricks.Client)
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		lan, err := client.CreateLan(rs.Primary.ID, profitbricks.Lan{
+			Properties: profitbricks.LanProperties{
+				Name:   "out-of-band",
+				Public: true,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("Error creating LAN out of band: %s", err)
+		}
+
+		if err := client.WaitTillProvisioned(lan.Headers.Get("Location")); err != nil {
+			return err
+		}
+
+		*dcId = rs.Primary.ID
+		*lanId = lan.ID
+		return nil
+	}
+}
+
 func testAccProfitBricksLanImportStateId(s *terraform.State) (string, error) {
 	var importID string = ""
 
@@ -44,3 +108,21 @@ func testAccProfitBricksLanImportStateId(s *terraform.State) (string, error) {
 
 	return importID, nil
 }
+
+const testAccCheckProfitbricksLanConfig_outOfBandDatacenter = `
+resource "profitbricks_datacenter" "foobar" {
+	name       = "lan-import-test"
+	location = "us/las"
+}`
+
+const testAccCheckProfitbricksLanConfig_outOfBand = `
+resource "profitbricks_datacenter" "foobar" {
+	name       = "lan-import-test"
+	location = "us/las"
+}
+
+resource "profitbricks_lan" "imported" {
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  public = true
+  name = "out-of-band"
+}`
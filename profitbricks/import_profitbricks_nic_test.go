@@ -2,7 +2,8 @@ package profitbricks
 
 import (
 	"fmt"
-
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
@@ -27,6 +28,28 @@ func TestAccProfitBricksNic_ImportBasic(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName: "profitbricks_nic.database_nic",
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					id, err := testAccProfitBricksNicImportStateId(s)
+					return id[:strings.LastIndex(id, "/")+1], err
+				},
+				ImportState: true,
+				ExpectError: regexp.MustCompile(`Invalid import id`),
+			},
+			{
+				ResourceName: "profitbricks_nic.database_nic",
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					id, err := testAccProfitBricksNicImportStateId(s)
+					return id[:strings.LastIndex(id, "/")+1] + "00000000-0000-0000-0000-000000000000", err
+				},
+				ImportState: true,
+				ExpectError: regexp.MustCompile(`Unable to find nic`),
+			},
+			{
+				Config:   fmt.Sprintf(testAccCheckProfitbricksNicConfig_basic, volumeName),
+				PlanOnly: true,
+			},
 		},
 	})
 }
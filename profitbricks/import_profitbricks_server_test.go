@@ -28,6 +28,13 @@ func TestAccProfitBricksServer_ImportBasic(t *testing.T) {
 				ImportStateVerify:       true,
 				ImportStateVerifyIgnore: []string{"image_password", "ssh_key_path.#", "image_name"},
 			},
+			{
+				ResourceName:            "profitbricks_server.webserver",
+				ImportStateIdFunc:       testAccProfitBricksServerImportStateIdShort,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"image_password", "ssh_key_path.#", "image_name"},
+			},
 		},
 	})
 }
@@ -45,3 +52,17 @@ func testAccProfitBricksServerImportStateId(s *terraform.State) (string, error)
 
 	return importID, nil
 }
+
+func testAccProfitBricksServerImportStateIdShort(s *terraform.State) (string, error) {
+	var importID string = ""
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "profitbricks_server" {
+			continue
+		}
+
+		importID = fmt.Sprintf("%s/%s", rs.Primary.Attributes["datacenter_id"], rs.Primary.Attributes["id"])
+	}
+
+	return importID, nil
+}
@@ -0,0 +1,33 @@
+package profitbricks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccProfitBricksSnapshot_ImportBasic(t *testing.T) {
+	snapshotName := "terraform_snapshot"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksSnapshotDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckProfitbricksSnapshotConfig_basic, snapshotName),
+			},
+			{
+				ResourceName:            "profitbricks_snapshot.test_snapshot",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"datacenter_id", "volume_id"},
+			},
+			{
+				Config:   fmt.Sprintf(testAccCheckProfitbricksSnapshotConfig_basic, snapshotName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
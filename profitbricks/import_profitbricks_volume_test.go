@@ -0,0 +1,40 @@
+package profitbricks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccProfitBricksVolume_ImportBasic(t *testing.T) {
+	volumeName := "volume"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksVolumeDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckProfitbricksVolumeConfig_basic, volumeName),
+			},
+			{
+				ResourceName:            "profitbricks_volume.database_volume",
+				ImportStateIdFunc:       testAccProfitBricksVolumeImportStateId,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"image_password", "ssh_key_path.#", "ssh_keys.#", "user_data", "user_data_base64"},
+			},
+		},
+	})
+}
+
+func testAccProfitBricksVolumeImportStateId(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources["profitbricks_volume.database_volume"]
+	if !ok {
+		return "", fmt.Errorf("Not found: profitbricks_volume.database_volume")
+	}
+
+	return fmt.Sprintf("%s/%s", rs.Primary.Attributes["datacenter_id"], rs.Primary.ID), nil
+}
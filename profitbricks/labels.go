@@ -0,0 +1,193 @@
+package profitbricks
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
+)
+
+// labels are not modeled by profitbricks-sdk-go yet, they are key/value pairs kept below the
+// labels path of the resource they are attached to, e.g. /datacenters/{id}/labels
+
+type labelProperties struct {
+	Key          string `json:"key,omitempty"`
+	Value        string `json:"value,omitempty"`
+	ResourceID   string `json:"resourceId,omitempty"`
+	ResourceType string `json:"resourceType,omitempty"`
+	ResourceHref string `json:"resourceHref,omitempty"`
+}
+
+type label struct {
+	ID         string          `json:"id,omitempty"`
+	Properties labelProperties `json:"properties"`
+}
+
+type labels struct {
+	Items []label `json:"items,omitempty"`
+	Links struct {
+		Next string `json:"next,omitempty"`
+	} `json:"_links,omitempty"`
+}
+
+// labelsSchema is the schema of the labels attribute of resources labels can be attached to. It is computed,
+// so resources which do not set it leave their labels, e.g. those of profitbricks_label, alone
+func labelsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Computed:    true,
+		Description: "Labels attached to the resource, as a map of keys to values.",
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+func datacenterLabelsPath(dcId string) string {
+	return fmt.Sprintf("/datacenters/%s/labels", dcId)
+}
+
+func serverLabelsPath(dcId string, serverId string) string {
+	return fmt.Sprintf("/datacenters/%s/servers/%s/labels", dcId, serverId)
+}
+
+func volumeLabelsPath(dcId string, volumeId string) string {
+	return fmt.Sprintf("/datacenters/%s/volumes/%s/labels", dcId, volumeId)
+}
+
+// labelResourceTypes are the types of resources labels can be attached to
+var labelResourceTypes = []string{"datacenter", "server", "volume", "ipblock", "snapshot"}
+
+// labelsPath returns the labels path of a resource, servers and volumes are found in their data center
+func labelsPath(resourceType string, dcId string, resourceId string) (string, error) {
+	switch resourceType {
+	case "datacenter":
+		return datacenterLabelsPath(resourceId), nil
+	case "server", "volume":
+		if dcId == "" {
+			return "", fmt.Errorf("datacenter_id is required for labels of a %s", resourceType)
+		}
+		if resourceType == "server" {
+			return serverLabelsPath(dcId, resourceId), nil
+		}
+		return volumeLabelsPath(dcId, resourceId), nil
+	case "ipblock":
+		return fmt.Sprintf("/ipblocks/%s/labels", resourceId), nil
+	case "snapshot":
+		return fmt.Sprintf("/snapshots/%s/labels", resourceId), nil
+	}
+	return "", fmt.Errorf("Labels cannot be attached to resources of type %q, expecting one of %s", resourceType, strings.Join(labelResourceTypes, ", "))
+}
+
+// listLabels returns all labels below path, following the pages of the collection
+func listLabels(client *profitbricks.Client, path string) ([]label, error) {
+	items := []label{}
+	for path != "" {
+		page := &labels{}
+		if err := client.Get(path, page, http.StatusOK); err != nil {
+			return nil, err
+		}
+		items = append(items, page.Items...)
+		path = page.Links.Next
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Properties.Key < items[j].Properties.Key
+	})
+	return items, nil
+}
+
+// getLabels returns the labels below path as a map of keys to values
+func getLabels(client *profitbricks.Client, path string) (map[string]string, error) {
+	items, err := listLabels(client, path)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := map[string]string{}
+	for _, l := range items {
+		ret[l.Properties.Key] = l.Properties.Value
+	}
+	return ret, nil
+}
+
+func getLabel(client *profitbricks.Client, path string, key string) (*label, error) {
+	ret := &label{}
+	err := client.Get(fmt.Sprintf("%s/%s", path, url.PathEscape(key)), ret, http.StatusOK)
+	return ret, err
+}
+
+func createLabel(client *profitbricks.Client, path string, key string, value string) (*label, error) {
+	ret := &label{}
+	body := label{Properties: labelProperties{Key: key, Value: value}}
+	err := client.Post(path, body, ret, http.StatusCreated)
+	return ret, err
+}
+
+func updateLabel(client *profitbricks.Client, path string, key string, value string) (*label, error) {
+	ret := &label{}
+	body := label{Properties: labelProperties{Key: key, Value: value}}
+	err := client.Put(fmt.Sprintf("%s/%s", path, url.PathEscape(key)), body, ret, http.StatusOK)
+	return ret, err
+}
+
+func deleteLabel(client *profitbricks.Client, path string, key string) error {
+	return client.Delete(fmt.Sprintf("%s/%s", path, url.PathEscape(key)), nil, http.StatusAccepted)
+}
+
+// updateLabels changes the labels below path from the old to the new value of the labels attribute,
+// labels which were not changed are left alone
+func updateLabels(client *profitbricks.Client, d *schema.ResourceData, path string) error {
+	if !d.HasChange("labels") {
+		return nil
+	}
+	o, n := d.GetChange("labels")
+	oldLabels := o.(map[string]interface{})
+	newLabels := n.(map[string]interface{})
+
+	for key := range oldLabels {
+		if _, ok := newLabels[key]; ok {
+			continue
+		}
+		log.Printf("[INFO] Removing label %s from %s", key, path)
+		if err := deleteLabel(client, path, key); err != nil {
+			if apiError, ok := err.(profitbricks.ApiError); ok && apiError.HttpStatusCode() == 404 {
+				continue
+			}
+			return fmt.Errorf("An error occured while removing label %s from %s: %w", key, path, err)
+		}
+	}
+
+	for key, value := range newLabels {
+		oldValue, exists := oldLabels[key]
+		if exists && oldValue == value {
+			continue
+		}
+		log.Printf("[INFO] Setting label %s=%s on %s", key, value, path)
+		var err error
+		if exists {
+			_, err = updateLabel(client, path, key, value.(string))
+		} else {
+			_, err = createLabel(client, path, key, value.(string))
+		}
+		if err != nil {
+			return fmt.Errorf("An error occured while setting label %s on %s: %w", key, path, err)
+		}
+	}
+
+	return nil
+}
+
+// readLabels sets the labels attribute to all labels below path, so labels added elsewhere show up as a change
+// of resources which set the labels attribute
+func readLabels(client *profitbricks.Client, d *schema.ResourceData, path string) error {
+	labels, err := getLabels(client, path)
+	if err != nil {
+		return fmt.Errorf("An error occured while fetching the labels of %s: %w", path, err)
+	}
+	return d.Set("labels", labels)
+}
@@ -1,8 +1,12 @@
 package profitbricks
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
@@ -42,11 +46,117 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("PROFITBRICKS_API_URL", ""),
 				Description: "ProfitBricks REST API URL.",
 			},
+			"contract_number": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PROFITBRICKS_CONTRACT", ""),
+				Description: "The contract number requests are made for, needed by users with access to several contracts.",
+			},
+			"http_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     180,
+				Description: "Timeout of a single http request to the API in seconds.",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if v.(int) < 1 {
+						errors = append(errors, fmt.Errorf("%q must be at least 1, got %d", k, v.(int)))
+					}
+					return
+				},
+			},
+			"max_retries": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     3,
+				Description: "Number of times a failed http request to the API is retried when the API is rate limiting or answers with 502, 503 or 504.",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if v.(int) < 0 {
+						errors = append(errors, fmt.Errorf("%q must not be negative, got %d", k, v.(int)))
+					}
+					return
+				},
+			},
+			"retry_wait_min": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1,
+				Description: "Minimum number of seconds to wait before retrying a failed http request.",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if v.(int) < 0 {
+						errors = append(errors, fmt.Errorf("%q must not be negative, got %d", k, v.(int)))
+					}
+					return
+				},
+			},
+			"retry_wait_max": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     600,
+				Description: "Maximum number of seconds to wait before retrying a failed http request.",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if v.(int) < 0 {
+						errors = append(errors, fmt.Errorf("%q must not be negative, got %d", k, v.(int)))
+					}
+					return
+				},
+			},
+			"poll_interval": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Seconds between checks of the status of a request, defaults to 10. Values below 1 are raised to 1.",
+			},
 			"retries": {
-				Type:       schema.TypeInt,
-				Optional:   true,
-				Default:    50,
-				Deprecated: "Timeout is used instead of this functionality",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     3,
+				Description: "Number of times checking the status of a request is retried when the API answers with a server error which is not retried according to max_retries, e.g. 500, or the connection fails, with an exponential backoff starting at retry_wait_min.",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if v.(int) < 0 {
+						errors = append(errors, fmt.Errorf("%q must not be negative, got %d", k, v.(int)))
+					}
+					return
+				},
+			},
+			"unique_datacenter_names": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to reject a data center with the same name as an existing data center in the same location.",
+			},
+			"check_capacity": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to check when planning servers that their location offers the cpu family, cores and ram, and that the contract has enough of them left.",
+			},
+			"resource_timeouts": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Overrides the default create, update and delete timeouts of a resource type, in minutes. A timeouts block of a single resource still takes precedence.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The resource type the timeouts apply to, e.g. profitbricks_server.",
+						},
+						"create": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validateTimeoutMinutes,
+						},
+						"update": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validateTimeoutMinutes,
+						},
+						"delete": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validateTimeoutMinutes,
+						},
+					},
+				},
 			},
 		},
 
@@ -60,10 +170,13 @@ func Provider() terraform.ResourceProvider {
 			"profitbricks_server":               resourceProfitBricksServer(),
 			"profitbricks_volume":               resourceProfitBricksVolume(),
 			"profitbricks_group":                resourceProfitBricksGroup(),
+			"profitbricks_group_membership":     resourceProfitBricksGroupMembership(),
 			"profitbricks_share":                resourceProfitBricksShare(),
 			"profitbricks_user":                 resourceProfitBricksUser(),
 			"profitbricks_snapshot":             resourceProfitBricksSnapshot(),
+			"profitbricks_image":                resourceProfitBricksImage(),
 			"profitbricks_ipfailover":           resourceProfitBricksLanIPFailover(),
+			"profitbricks_label":                resourceProfitBricksLabel(),
 			"profitbricks_k8s_cluster":          resourcek8sCluster(),
 			"profitbricks_k8s_node_pool":        resourcek8sNodePool(),
 			"profitbricks_private_crossconnect": resourcePrivateCrossConnect(),
@@ -71,14 +184,32 @@ func Provider() terraform.ResourceProvider {
 			"profitbricks_s3_key":               resourceS3Key(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"profitbricks_datacenter": dataSourceDataCenter(),
-			"profitbricks_location":   dataSourceLocation(),
-			"profitbricks_image":      dataSourceImage(),
-			"profitbricks_resource":   dataSourceResource(),
-			"profitbricks_snapshot":   dataSourceSnapshot(),
+			"profitbricks_contract":             dataSourceContract(),
+			"profitbricks_datacenter":           dataSourceDataCenter(),
+			"profitbricks_firewall":             dataSourceFirewall(),
+			"profitbricks_labels":               dataSourceLabels(),
+			"profitbricks_lan":                  dataSourceLan(),
+			"profitbricks_location":             dataSourceLocation(),
+			"profitbricks_group":                dataSourceGroup(),
+			"profitbricks_image":                dataSourceImage(),
+			"profitbricks_ipblock":              dataSourceIPBlock(),
+			"profitbricks_k8s_cluster":          dataSourceK8sCluster(),
+			"profitbricks_private_crossconnect": dataSourcePrivateCrossConnect(),
+			"profitbricks_quota":                dataSourceQuota(),
+			"profitbricks_request":              dataSourceRequest(),
+			"profitbricks_resource":             dataSourceResource(),
+			"profitbricks_server":               dataSourceServer(),
+			"profitbricks_share":                dataSourceShare(),
+			"profitbricks_snapshot":             dataSourceSnapshot(),
+			"profitbricks_user":                 dataSourceUser(),
+			"profitbricks_volume":               dataSourceVolume(),
 		},
 	}
 
+	for _, r := range provider.ResourcesMap {
+		withErrorDetails(r)
+	}
+
 	provider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
 
 		terraformVersion := provider.TerraformVersion
@@ -91,6 +222,10 @@ func Provider() terraform.ResourceProvider {
 
 		log.Printf("[DEBUG] Setting terraformVersion to %s", terraformVersion)
 
+		if err := applyResourceTimeouts(provider.ResourcesMap, d.Get("resource_timeouts").([]interface{})); err != nil {
+			return nil, err
+		}
+
 		return providerConfigure(d, terraformVersion)
 	}
 
@@ -123,11 +258,75 @@ func providerConfigure(d *schema.ResourceData, terraformVersion string) (interfa
 		Endpoint: cleanURL(d.Get("endpoint").(string)),
 		Retries:  d.Get("retries").(int),
 		Token:    token.(string),
+
+		ContractNumber: d.Get("contract_number").(string),
+		HTTPTimeout:    d.Get("http_timeout").(int),
+		MaxRetries:     d.Get("max_retries").(int),
+		RetryWaitMin:   d.Get("retry_wait_min").(int),
+		RetryWaitMax:   d.Get("retry_wait_max").(int),
+
+		UniqueDatacenterNames: d.Get("unique_datacenter_names").(bool),
+		CheckCapacity:         d.Get("check_capacity").(bool),
+	}
+
+	if config.RetryWaitMin > config.RetryWaitMax {
+		return nil, fmt.Errorf("retry_wait_min (%d) must not be greater than retry_wait_max (%d)", config.RetryWaitMin, config.RetryWaitMax)
+	}
+
+	if v, ok := d.GetOkExists("poll_interval"); ok {
+		config.PollInterval = v.(int)
+		if config.PollInterval < minPollInterval {
+			log.Printf("[WARN] poll_interval %d is too low, using %d instead", config.PollInterval, minPollInterval)
+			config.PollInterval = minPollInterval
+		}
 	}
 
 	return config.Client(terraformVersion)
 }
 
+// applyResourceTimeouts replaces the default timeouts of the configured resource types, the
+// defaults are read from the resource schema when a resource is planned, after the provider is configured
+func applyResourceTimeouts(resources map[string]*schema.Resource, overrides []interface{}) error {
+	seen := map[string]bool{}
+	for _, raw := range overrides {
+		override := raw.(map[string]interface{})
+		name := override["resource"].(string)
+
+		r, ok := resources[name]
+		if !ok {
+			return fmt.Errorf("resource_timeouts: unknown resource type %q", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("resource_timeouts: timeouts for %q are set more than once", name)
+		}
+		seen[name] = true
+
+		timeouts := resourceDefaultTimeouts
+		if v := override["create"].(int); v > 0 {
+			timeouts.Create = schema.DefaultTimeout(time.Duration(v) * time.Minute)
+		}
+		if v := override["update"].(int); v > 0 {
+			timeouts.Update = schema.DefaultTimeout(time.Duration(v) * time.Minute)
+		}
+		if v := override["delete"].(int); v > 0 {
+			timeouts.Delete = schema.DefaultTimeout(time.Duration(v) * time.Minute)
+		}
+
+		log.Printf("[INFO] Using timeouts create %s, update %s, delete %s for %s", *timeouts.Create, *timeouts.Update, *timeouts.Delete, name)
+		r.Timeouts = &timeouts
+	}
+
+	return nil
+}
+
+// validateTimeoutMinutes checks a timeout given in minutes is positive
+func validateTimeoutMinutes(v interface{}, k string) (ws []string, errors []error) {
+	if v.(int) < 1 {
+		errors = append(errors, fmt.Errorf("%q must be at least 1 minute, got %d", k, v.(int)))
+	}
+	return
+}
+
 // cleanURL makes sure trailing slash does not corrupte the state
 func cleanURL(url string) string {
 	length := len(url)
@@ -145,9 +344,9 @@ func getStateChangeConf(meta interface{}, d *schema.ResourceData, location strin
 		Target:         resourceTargetStates,
 		Refresh:        resourceStateRefreshFunc(meta, location),
 		Timeout:        d.Timeout(timeoutType),
-		MinTimeout:     10 * time.Second,
-		Delay:          10 * time.Second, // Wait 10 secs before starting
-		NotFoundChecks: 600,              //Setting high number, to support long timeouts
+		MinTimeout:     pollInterval(meta.(*profitbricks.Client), 10*time.Second),
+		Delay:          pollInterval(meta.(*profitbricks.Client), 10*time.Second), // Wait before starting
+		NotFoundChecks: 600,                                                       //Setting high number, to support long timeouts
 	}
 
 	return stateConf
@@ -155,6 +354,8 @@ func getStateChangeConf(meta interface{}, d *schema.ResourceData, location strin
 
 type RequestFailedError struct {
 	msg string
+	// requestID is the id of the failed request, support needs it to look into the failure
+	requestID string
 }
 
 func (e RequestFailedError) Error() string {
@@ -162,8 +363,121 @@ func (e RequestFailedError) Error() string {
 }
 
 func IsRequestFailed(err error) bool {
-	_, ok := err.(RequestFailedError)
-	return ok
+	var requestFailed RequestFailedError
+	return errors.As(err, &requestFailed)
+}
+
+var requestIDRegexp = regexp.MustCompile(`/requests/([^/]+)/status`)
+
+// requestID returns the id of the request whose status is at path, or an empty string
+func requestID(path string) string {
+	if match := requestIDRegexp.FindStringSubmatch(path); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// detailedError keeps the error it adds details to, so it can still be inspected with errors.As
+type detailedError struct {
+	msg string
+	err error
+}
+
+func (e detailedError) Error() string {
+	return e.msg
+}
+
+func (e detailedError) Unwrap() error {
+	return e.err
+}
+
+// errorDetails replaces the multi-line description of an API error in err by a single line with
+// the HTTP status and the error codes, and adds the id of a failed request
+func errorDetails(err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+
+	var apiError profitbricks.ApiError
+	if errors.As(err, &apiError) {
+		details := fmt.Sprintf("HTTP status %d", apiError.HttpStatusCode())
+		for _, m := range apiError.Messages {
+			details += fmt.Sprintf(", error code %s: %s", m.ErrorCode, m.Message)
+		}
+		msg = strings.Replace(msg, apiError.Error(), details, 1)
+	}
+
+	var requestFailed RequestFailedError
+	if errors.As(err, &requestFailed) && requestFailed.requestID != "" {
+		msg += fmt.Sprintf(" (request id %s)", requestFailed.requestID)
+	}
+
+	if msg == err.Error() {
+		return err
+	}
+	return detailedError{msg: msg, err: err}
+}
+
+// withErrorDetails makes the CRUD functions of r return their errors with errorDetails
+func withErrorDetails(r *schema.Resource) {
+	wrap := func(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+		if f == nil {
+			return nil
+		}
+		return func(d *schema.ResourceData, meta interface{}) error {
+			return errorDetails(f(d, meta))
+		}
+	}
+
+	r.Create = wrap(r.Create)
+	r.Read = wrap(r.Read)
+	r.Update = wrap(r.Update)
+	r.Delete = wrap(r.Delete)
+}
+
+// waitForRequest waits until the request whose status is at location is done. Server errors and
+// network errors while checking the status are retried, client errors and failed requests are not
+func waitForRequest(meta interface{}, d *schema.ResourceData, location string, timeoutType string) (interface{}, error) {
+	return getStateChangeConf(meta, d, location, timeoutType).WaitForState()
+}
+
+// isTransientError reports whether a request failing with err may succeed when sent again. Rate limiting
+// and 502, 503 and 504 are already retried by the http client as configured by max_retries, so only the
+// other server errors and network errors are left to retry
+func isTransientError(err error) bool {
+	var apiError profitbricks.ApiError
+	if errors.As(err, &apiError) {
+		switch apiError.HttpStatusCode() {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return false
+		}
+		return apiError.HttpStatusCode() >= 500
+	}
+	return profitbricks.IsClientErrorType(err, profitbricks.HttpClientError)
+}
+
+// getWithRetries runs the idempotent GET done by get again while it fails with a transient error the http
+// client does not retry itself, at most as often as configured by retries and waiting twice as long after
+// every attempt. Each error is retried by one of them only, so the attempts do not multiply
+func getWithRetries(client *profitbricks.Client, get func() error) error {
+	retries := clientRetries(client)
+	wait := client.RetryWaitTime
+
+	for attempt := 1; ; attempt++ {
+		err := get()
+		if err == nil || !isTransientError(err) || attempt > retries {
+			return err
+		}
+
+		log.Printf("[WARN] GET failed with %s, retrying in %s (%d/%d)", err, wait, attempt, retries)
+		time.Sleep(wait)
+
+		wait *= 2
+		if wait > client.RetryMaxWaitTime {
+			wait = client.RetryMaxWaitTime
+		}
+	}
 }
 
 // resourceStateRefreshFunc tracks progress of a request
@@ -176,14 +490,21 @@ func resourceStateRefreshFunc(meta interface{}, path string) resource.StateRefre
 			return nil, "", fmt.Errorf("Can not check a state when path is empty")
 		}
 
-		request, err := client.GetRequestStatus(path)
+		var request *profitbricks.RequestStatus
+		err := getWithRetries(client, func() (err error) {
+			request, err = client.GetRequestStatus(path)
+			return err
+		})
 
 		if err != nil {
-			return nil, "", fmt.Errorf("Request failed with following error: %s", err)
+			return nil, "", fmt.Errorf("Request failed with following error: %w", err)
 		}
 
 		if request.Metadata.Status == "FAILED" {
-			return nil, "", RequestFailedError{fmt.Sprintf("Request failed with following error: %s", request.Metadata.Message)}
+			return nil, "", RequestFailedError{
+				msg:       fmt.Sprintf("Request failed with following error: %s", request.Metadata.Message),
+				requestID: requestID(path),
+			}
 		}
 
 		if request.Metadata.Status == "DONE" {
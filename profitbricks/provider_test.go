@@ -1,11 +1,19 @@
 package profitbricks
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	resty "github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
 )
 
 var testAccProviders map[string]terraform.ResourceProvider
@@ -28,6 +36,252 @@ func TestProvider_impl(t *testing.T) {
 	var _ terraform.ResourceProvider = Provider()
 }
 
+func TestProvider_pollInterval(t *testing.T) {
+	config := Config{Token: "token", PollInterval: 3}
+	client, err := config.Client("0.12")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if interval := pollInterval(client, 10*time.Second); interval != 3*time.Second {
+		t.Fatalf("expected the configured poll interval of 3s, got %s", interval)
+	}
+
+	config = Config{Token: "token"}
+	client, err = config.Client("0.12")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if interval := pollInterval(client, 10*time.Second); interval != 10*time.Second {
+		t.Fatalf("expected the default poll interval of 10s, got %s", interval)
+	}
+}
+
+func TestProvider_contractNumber(t *testing.T) {
+	config := Config{Token: "token", ContractNumber: "31415926"}
+	client, err := config.Client("0.12")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if contractNumber := client.Header.Get("X-Contract-Number"); contractNumber != "31415926" {
+		t.Fatalf("expected the contract number header to be 31415926, got %q", contractNumber)
+	}
+	if userAgent := client.GetUserAgent(); !strings.Contains(userAgent, "terraform-provider-profitbricks/"+ProviderVersion) {
+		t.Fatalf("expected the user agent to contain the provider version, got %q", userAgent)
+	}
+}
+
+func TestProvider_httpSettings(t *testing.T) {
+	config := Config{Token: "token", HTTPTimeout: 30, MaxRetries: 5, RetryWaitMin: 2, RetryWaitMax: 60}
+	client, err := config.Client("0.12")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if client.RetryCount != 5 {
+		t.Fatalf("expected 5 retries, got %d", client.RetryCount)
+	}
+	if client.RetryWaitTime != 2*time.Second || client.RetryMaxWaitTime != 60*time.Second {
+		t.Fatalf("expected to wait between 2s and 1m0s before retrying, got %s and %s", client.RetryWaitTime, client.RetryMaxWaitTime)
+	}
+}
+
+func TestProvider_requestStatusRetries(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if calls == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"httpStatus": 500, "messages": [{"errorCode": "000", "message": "Internal Server Error"}]}`))
+			return
+		}
+		w.Write([]byte(`{"metadata": {"status": "DONE"}}`))
+	}))
+	defer server.Close()
+
+	config := Config{Token: "token", Endpoint: server.URL, Retries: 2}
+	client, err := config.Client("0.12")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	_, state, err := resourceStateRefreshFunc(client, server.URL+"/requests/1/status")()
+	if err != nil {
+		t.Fatalf("expected the 500 to be retried, got err: %s", err)
+	}
+	if state != "DONE" {
+		t.Fatalf("expected state DONE, got %q", state)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestProvider_requestStatusTransientErrors(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			// drop the connection, as happens when a load balancer times out
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if calls == 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"httpStatus": 503, "messages": [{"errorCode": "000", "message": "Service Unavailable"}]}`))
+			return
+		}
+		w.Write([]byte(`{"metadata": {"status": "DONE"}}`))
+	}))
+	defer server.Close()
+
+	config := Config{Token: "token", Endpoint: server.URL, MaxRetries: 2, RetryWaitMin: 1, RetryWaitMax: 1, Retries: 2, PollInterval: 1}
+	client, err := config.Client("0.12")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	d := Provider().(*schema.Provider).ResourcesMap["profitbricks_lan"].Data(nil)
+	if _, err := waitForRequest(client, d, server.URL+"/requests/1/status", schema.TimeoutCreate); err != nil {
+		t.Fatalf("expected the dropped connection and the 503 to be retried, got err: %s", err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestProvider_requestStatusRetriesDoNotMultiply(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"httpStatus": 503, "messages": [{"errorCode": "000", "message": "Service Unavailable"}]}`))
+	}))
+	defer server.Close()
+
+	config := Config{Token: "token", Endpoint: server.URL, MaxRetries: 2, RetryWaitMin: 1, RetryWaitMax: 1, Retries: 2}
+	client, err := config.Client("0.12")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	_, _, err = resourceStateRefreshFunc(client, server.URL+"/requests/1/status")()
+	if err == nil {
+		t.Fatalf("expected the 503 to be returned")
+	}
+	if calls != 3 {
+		t.Fatalf("expected the 503 to be retried by max_retries only, got %d calls", calls)
+	}
+}
+
+func TestProvider_requestStatusFatalErrors(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(r.URL.Path, "/requests/1/") {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"httpStatus": 403, "messages": [{"errorCode": "315", "message": "Access denied"}]}`))
+			return
+		}
+		w.Write([]byte(`{"metadata": {"status": "FAILED", "message": "no capacity left"}}`))
+	}))
+	defer server.Close()
+
+	config := Config{Token: "token", Endpoint: server.URL, Retries: 2}
+	client, err := config.Client("0.12")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	_, _, err = resourceStateRefreshFunc(client, server.URL+"/requests/1/status")()
+	if !profitbricks.IsStatusForbidden(err) {
+		t.Fatalf("expected the 403 to be returned, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected the 403 not to be retried, got %d calls", calls)
+	}
+
+	calls = 0
+	_, _, err = resourceStateRefreshFunc(client, server.URL+"/requests/2/status")()
+	if !IsRequestFailed(err) {
+		t.Fatalf("expected the failed request to be returned, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected the failed request not to be retried, got %d calls", calls)
+	}
+}
+
+func TestProvider_rateLimit(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"httpStatus": 429, "messages": [{"errorCode": "429", "message": "Too many requests"}]}`))
+			return
+		}
+		w.Write([]byte(`{"metadata": {"status": "DONE"}}`))
+	}))
+	defer server.Close()
+
+	config := Config{Token: "token", Endpoint: server.URL, MaxRetries: 2, RetryWaitMax: 5}
+	client, err := config.Client("0.12")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	start := time.Now()
+	_, state, err := resourceStateRefreshFunc(client, server.URL+"/requests/1/status")()
+	if err != nil {
+		t.Fatalf("expected the 429 to be retried, got err: %s", err)
+	}
+	if state != "DONE" {
+		t.Fatalf("expected state DONE, got %q", state)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", calls)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("expected to wait the second asked for by Retry-After, waited %s", elapsed)
+	}
+}
+
+func TestProvider_retryAfter(t *testing.T) {
+	response := func(status int, retryAfter string) *resty.Response {
+		header := http.Header{}
+		if retryAfter != "" {
+			header.Set("Retry-After", retryAfter)
+		}
+		return &resty.Response{RawResponse: &http.Response{StatusCode: status, Header: header}}
+	}
+
+	for _, c := range []struct {
+		response *resty.Response
+		min, max time.Duration
+	}{
+		{response(http.StatusTooManyRequests, "30"), 30 * time.Second, 30 * time.Second},
+		{response(http.StatusTooManyRequests, time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)), 58 * time.Second, time.Minute},
+		{response(http.StatusTooManyRequests, time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)), 0, 0},
+		{response(http.StatusTooManyRequests, "soon"), 0, 0},
+		{response(http.StatusTooManyRequests, ""), 0, 0},
+		{response(http.StatusServiceUnavailable, "30"), 0, 0},
+	} {
+		wait, err := retryAfter(nil, c.response)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if wait < c.min || wait > c.max {
+			t.Errorf("expected to wait between %s and %s for Retry-After %q and status %d, got %s",
+				c.min, c.max, c.response.Header().Get("Retry-After"), c.response.StatusCode(), wait)
+		}
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	pbUsername := os.Getenv("PROFITBRICKS_USERNAME")
 	pbPassword := os.Getenv("PROFITBRICKS_PASSWORD")
@@ -43,3 +297,161 @@ func testAccPreCheck(t *testing.T) {
 
 	}
 }
+
+func TestProvider_resourceTimeouts(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	overrides := []interface{}{
+		map[string]interface{}{"resource": "profitbricks_server", "create": 90, "update": 0, "delete": 15},
+	}
+	if err := applyResourceTimeouts(provider.ResourcesMap, overrides); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	d := provider.ResourcesMap["profitbricks_server"].Data(nil)
+	if timeout := d.Timeout(schema.TimeoutCreate); timeout != 90*time.Minute {
+		t.Fatalf("expected a create timeout of 1h30m0s, got %s", timeout)
+	}
+	if timeout := d.Timeout(schema.TimeoutUpdate); timeout != 60*time.Minute {
+		t.Fatalf("expected the default update timeout of 1h0m0s, got %s", timeout)
+	}
+	if timeout := d.Timeout(schema.TimeoutDelete); timeout != 15*time.Minute {
+		t.Fatalf("expected a delete timeout of 15m0s, got %s", timeout)
+	}
+
+	d = provider.ResourcesMap["profitbricks_volume"].Data(nil)
+	if timeout := d.Timeout(schema.TimeoutCreate); timeout != 60*time.Minute {
+		t.Fatalf("expected the default create timeout of 1h0m0s for volumes, got %s", timeout)
+	}
+
+	overrides = []interface{}{
+		map[string]interface{}{"resource": "profitbricks_servers", "create": 90, "update": 0, "delete": 0},
+	}
+	if err := applyResourceTimeouts(provider.ResourcesMap, overrides); err == nil || !strings.Contains(err.Error(), "unknown resource type") {
+		t.Fatalf("expected an error for an unknown resource type, got %v", err)
+	}
+
+	overrides = append(overrides[:0],
+		map[string]interface{}{"resource": "profitbricks_lan", "create": 5, "update": 0, "delete": 0},
+		map[string]interface{}{"resource": "profitbricks_lan", "create": 10, "update": 0, "delete": 0},
+	)
+	if err := applyResourceTimeouts(provider.ResourcesMap, overrides); err == nil || !strings.Contains(err.Error(), "more than once") {
+		t.Fatalf("expected an error for duplicate timeouts, got %v", err)
+	}
+}
+
+func TestProvider_errorDetails(t *testing.T) {
+	apiError := profitbricks.ApiError{HTTPStatus: 422}
+	apiError.Messages = append(apiError.Messages, struct {
+		ErrorCode string `json:"errorCode"`
+		Message   string `json:"message"`
+	}{ErrorCode: "100", Message: "[(root).properties.name] Attribute is required"})
+
+	err := errorDetails(fmt.Errorf("An error occured while creating a datacenter: %w", apiError))
+	expected := "An error occured while creating a datacenter: HTTP status 422, error code 100: [(root).properties.name] Attribute is required"
+	if err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err.Error())
+	}
+	if !profitbricks.IsStatusUnprocessableEntity(err) {
+		t.Fatalf("expected the api error to be kept, got %#v", err)
+	}
+
+	path := "https://api.profitbricks.com/cloudapi/v5/requests/5d4d3c12-6d0a-4b5b-8c39-4f1d8b4ae0c7/status"
+	err = errorDetails(fmt.Errorf("Error while waiting: %w", RequestFailedError{msg: "Request failed with following error: no capacity", requestID: requestID(path)}))
+	expected = "Error while waiting: Request failed with following error: no capacity (request id 5d4d3c12-6d0a-4b5b-8c39-4f1d8b4ae0c7)"
+	if err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err.Error())
+	}
+	if !IsRequestFailed(err) {
+		t.Fatalf("expected the failed request to be kept, got %#v", err)
+	}
+
+	plain := fmt.Errorf("Neither ProfitBricks token, nor ProfitBricks username has been provided")
+	if err := errorDetails(plain); err != plain {
+		t.Fatalf("expected an error without details to be returned as is, got %#v", err)
+	}
+}
+
+func TestProvider_lookupCache(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls[r.URL.Path]++
+		if r.URL.Path == "/locations" {
+			queries = append(queries, r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/locations":
+			w.Write([]byte(`{"items": [{"id": "us/las", "properties": {"name": "lasvegas"}}]}`))
+		case "/locations/us/las":
+			w.Write([]byte(`{"id": "us/las", "properties": {"name": "lasvegas", "imageAliases": ["ubuntu:latest"]}}`))
+		case "/images":
+			if calls[r.URL.Path] == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte(`{"httpStatus": 503, "messages": []}`))
+				return
+			}
+			w.Write([]byte(`{"items": [{"id": "image-id", "properties": {"name": "ubuntu"}}]}`))
+		}
+	}))
+	defer server.Close()
+
+	config := Config{Token: "token", Endpoint: server.URL}
+	client, err := config.Client("0.12")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := listLocationsWithExtras(client); err != nil {
+				t.Errorf("err: %s", err)
+			}
+			if alias := getImageAlias(client, "ubuntu:latest", "us/las"); alias != "ubuntu:latest" {
+				t.Errorf("expected the image alias to be found, got %q", alias)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls["/locations"] != 1 || calls["/locations/us/las"] != 1 {
+		t.Fatalf("expected the locations to be fetched once, got %v", calls)
+	}
+	if queries[0] != "depth=1" {
+		t.Fatalf("expected the locations to be listed with depth 1 only, got %q", queries[0])
+	}
+
+	if _, err := listImages(client); err == nil {
+		t.Fatalf("expected the 503 to be returned")
+	}
+	for i := 0; i < 2; i++ {
+		images, err := listImages(client)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if len(images.Items) != 1 {
+			t.Fatalf("expected one image, got %d", len(images.Items))
+		}
+	}
+	if calls["/images"] != 2 {
+		t.Fatalf("expected the failed lookup not to be cached, got %d calls", calls["/images"])
+	}
+
+	// every configured provider has a cache of its own
+	other, err := config.Client("0.12")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, err := listLocationsWithExtras(other); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if calls["/locations"] != 2 {
+		t.Fatalf("expected the locations to be fetched again by another provider, got %d calls", calls["/locations"])
+	}
+}
@@ -23,6 +23,7 @@ func resourceBackupUnit() *schema.Resource {
 				Type:        schema.TypeString,
 				Description: "Alphanumeric name you want assigned to the backup unit.",
 				Required:    true,
+				ForceNew:    true,
 			},
 			"password": {
 				Type:        schema.TypeString,
@@ -40,6 +41,11 @@ func resourceBackupUnit() *schema.Resource {
 				Description: "The login associated with the backup unit. Derived from the contract number",
 				Computed:    true,
 			},
+			"sso_url": {
+				Type:        schema.TypeString,
+				Description: "The single sign on URL of the backup management UI for the backup unit",
+				Computed:    true,
+			},
 		},
 		Timeouts: &resourceDefaultTimeouts,
 	}
@@ -60,7 +66,7 @@ func resourceBackupUnitCreate(d *schema.ResourceData, meta interface{}) error {
 
 	if err != nil {
 		d.SetId("")
-		return fmt.Errorf("Error creating backup unit: %s", err)
+		return fmt.Errorf("Error creating backup unit: %w", err)
 	}
 
 	d.SetId(createdBackupUnit.ID)
@@ -68,12 +74,12 @@ func resourceBackupUnitCreate(d *schema.ResourceData, meta interface{}) error {
 
 	for {
 		log.Printf("[INFO] Waiting for backup unit %s to be ready...", d.Id())
-		time.Sleep(5 * time.Second)
+		time.Sleep(pollInterval(client, 5*time.Second))
 
 		backupUnitReady, rsErr := backupUnitReady(client, d)
 
 		if rsErr != nil {
-			return fmt.Errorf("Error while checking readiness status of backup unit %s: %s", d.Id(), rsErr)
+			return fmt.Errorf("Error while checking readiness status of backup unit %s: %w", d.Id(), rsErr)
 		}
 
 		if backupUnitReady && rsErr == nil {
@@ -98,13 +104,13 @@ func resourceBackupUnitRead(d *schema.ResourceData, meta interface{}) error {
 			}
 		}
 
-		return fmt.Errorf("Error while fetching backup unit %s: %s", d.Id(), err)
+		return fmt.Errorf("Error while fetching backup unit %s: %w", d.Id(), err)
 	}
 
 	contractResources, cErr := client.GetContractResources()
 
 	if cErr != nil {
-		return fmt.Errorf("Error while fetching contract resources for backup unit %s: %s", d.Id(), cErr)
+		return fmt.Errorf("Error while fetching contract resources for backup unit %s: %w", d.Id(), cErr)
 	}
 
 	log.Printf("[INFO] Successfully retreived contract resource for backup unit unit %s: %+v", d.Id(), contractResources)
@@ -115,6 +121,14 @@ func resourceBackupUnitRead(d *schema.ResourceData, meta interface{}) error {
 
 	log.Printf("[INFO] Successfully retreived backup unit %s: %+v", d.Id(), backupUnit)
 
+	ssoURL, ssoErr := client.GetBackupUnitSSOURL(d.Id())
+
+	if ssoErr != nil {
+		return fmt.Errorf("Error while fetching the SSO URL for backup unit %s: %w", d.Id(), ssoErr)
+	}
+
+	d.Set("sso_url", ssoURL.SSOUrl)
+
 	return nil
 }
 
@@ -122,20 +136,21 @@ func resourceBackupUnitUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*profitbricks.Client)
 	request := profitbricks.BackupUnit{}
 
-	request.Properties = &profitbricks.BackupUnitProperties{}
+	// the backup unit is replaced as a whole, so email and password are always sent
+	request.Properties = &profitbricks.BackupUnitProperties{
+		Email:    d.Get("email").(string),
+		Password: d.Get("password").(string),
+	}
 
 	log.Printf("[INFO] Attempting update backup unit %s", d.Id())
 
 	if d.HasChange("email") {
 		oldEmail, newEmail := d.GetChange("email")
 		log.Printf("[INFO] backup unit email changed from %+v to %+v", oldEmail, newEmail)
-		request.Properties.Email = newEmail.(string)
 	}
 
 	if d.HasChange("password") {
-		oldPassword, newPassword := d.GetChange("password")
-		log.Printf("[INFO] backup unit password changed from %+v to %+v", oldPassword, newPassword)
-		request.Properties.Password = newPassword.(string)
+		log.Printf("[INFO] backup unit password changed")
 	}
 
 	_, err := client.UpdateBackupUnit(d.Id(), request)
@@ -146,19 +161,19 @@ func resourceBackupUnitUpdate(d *schema.ResourceData, meta interface{}) error {
 				d.SetId("")
 				return nil
 			}
-			return fmt.Errorf("Error while updating backup unit: %s", err)
+			return fmt.Errorf("Error while updating backup unit: %w", err)
 		}
-		return fmt.Errorf("Error while updating backup unit %s: %s", d.Id(), err)
+		return fmt.Errorf("Error while updating backup unit %s: %w", d.Id(), err)
 	}
 
 	for {
 		log.Printf("[INFO] Waiting for backup unit %s to be ready...", d.Id())
-		time.Sleep(5 * time.Second)
+		time.Sleep(pollInterval(client, 5*time.Second))
 
 		backupUnitReady, rsErr := backupUnitReady(client, d)
 
 		if rsErr != nil {
-			return fmt.Errorf("Error while checking readiness status of backup unit %s: %s", d.Id(), rsErr)
+			return fmt.Errorf("Error while checking readiness status of backup unit %s: %w", d.Id(), rsErr)
 		}
 
 		if backupUnitReady && rsErr == nil {
@@ -181,20 +196,20 @@ func resourceBackupUnitDelete(d *schema.ResourceData, meta interface{}) error {
 				d.SetId("")
 				return nil
 			}
-			return fmt.Errorf("Error while deleting backup unit: %s", err)
+			return fmt.Errorf("Error while deleting backup unit: %w", err)
 		}
 
-		return fmt.Errorf("Error while deleting backup unit %s: %s", d.Id(), err)
+		return fmt.Errorf("Error while deleting backup unit %s: %w", d.Id(), err)
 	}
 
 	for {
 		log.Printf("[INFO] Waiting for backupUnit %s to be deleted...", d.Id())
-		time.Sleep(5 * time.Second)
+		time.Sleep(pollInterval(client, 5*time.Second))
 
 		backupUnitDeleted, dsErr := backupUnitDeleted(client, d)
 
 		if dsErr != nil {
-			return fmt.Errorf("Error while checking deletion status of backup unit %s: %s", d.Id(), dsErr)
+			return fmt.Errorf("Error while checking deletion status of backup unit %s: %w", d.Id(), dsErr)
 		}
 
 		if backupUnitDeleted && dsErr == nil {
@@ -210,7 +225,7 @@ func backupUnitReady(client *profitbricks.Client, d *schema.ResourceData) (bool,
 	subjectBackupUnit, err := client.GetBackupUnit(d.Id())
 
 	if err != nil {
-		return true, fmt.Errorf("Error checking backup unit status: %s", err)
+		return true, fmt.Errorf("Error checking backup unit status: %w", err)
 	}
 	return subjectBackupUnit.Metadata.State == "AVAILABLE", nil
 }
@@ -223,7 +238,7 @@ func backupUnitDeleted(client *profitbricks.Client, d *schema.ResourceData) (boo
 			if apiError.HttpStatusCode() == 404 {
 				return true, nil
 			}
-			return true, fmt.Errorf("Error checking backup unit deletion status: %s", err)
+			return true, fmt.Errorf("Error checking backup unit deletion status: %w", err)
 		}
 	}
 	return false, nil
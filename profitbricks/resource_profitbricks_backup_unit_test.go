@@ -27,6 +27,7 @@ func TestAccProfitBricksbackupUnit_Basic(t *testing.T) {
 					resource.TestCheckResourceAttr("profitbricks_backup_unit.example", "name", backupUnitName),
 					resource.TestCheckResourceAttr("profitbricks_backup_unit.example", "email", "example@ionos.com"),
 					resource.TestCheckResourceAttr("profitbricks_backup_unit.example", "password", "DemoPassword123$"),
+					resource.TestCheckResourceAttrSet("profitbricks_backup_unit.example", "sso_url"),
 				),
 			},
 			{
@@ -3,6 +3,7 @@ package profitbricks
 import (
 	"fmt"
 	"log"
+	"net/http"
 	"regexp"
 	"strings"
 
@@ -17,8 +18,9 @@ func resourceProfitBricksDatacenter() *schema.Resource {
 		Update: resourceProfitBricksDatacenterUpdate,
 		Delete: resourceProfitBricksDatacenterDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceProfitBricksDatacenterImport,
 		},
+		CustomizeDiff: resourceProfitBricksDatacenterCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 
 			//Datacenter parameters
@@ -30,30 +32,78 @@ func resourceProfitBricksDatacenter() *schema.Resource {
 			"location": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"description": {
 				Type:     schema.TypeString,
 				Optional: true,
 				Computed: true,
 			},
+			"sec_auth_protection": {
+				Type:        schema.TypeBool,
+				Description: "Whether changes to the data center require secure (two-factor) authentication",
+				Optional:    true,
+				Default:     false,
+			},
+			"labels": labelsSchema(),
 		},
 		Timeouts: &resourceDefaultTimeouts,
 	}
 }
 
+// resourceProfitBricksDatacenterCustomizeDiff rejects a data center named like another one in the same
+// location when unique_datacenter_names is enabled, data sources looking up data centers by name would
+// find either of them
+func resourceProfitBricksDatacenterCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+	if !uniqueDatacenterNames(client) {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("name") {
+		return nil
+	}
+	if !d.NewValueKnown("name") || !d.NewValueKnown("location") {
+		return nil
+	}
+
+	name := d.Get("name").(string)
+	location := d.Get("location").(string)
+
+	datacenters, err := client.ListDatacenters()
+	if err != nil {
+		return fmt.Errorf("An error occured while fetching the data centers to check the name %q is unique: %w", name, err)
+	}
+
+	for _, dc := range datacenters.Items {
+		if dc.ID != d.Id() && dc.Properties.Name == name && dc.Properties.Location == location {
+			return fmt.Errorf("A data center named %q already exists in %s (%s), unique_datacenter_names is enabled", name, location, dc.ID)
+		}
+	}
+
+	return nil
+}
+
 func resourceProfitBricksDatacenterCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*profitbricks.Client)
-	datacenter := profitbricks.Datacenter{
-		Properties: profitbricks.DatacenterProperties{
-			Name:     d.Get("name").(string),
-			Location: d.Get("location").(string),
+	datacenter := datacenterWithExtras{
+		Properties: datacenterPropertiesWithExtras{
+			DatacenterProperties: profitbricks.DatacenterProperties{
+				Name:     d.Get("name").(string),
+				Location: d.Get("location").(string),
+			},
 		},
 	}
 
 	if attr, ok := d.GetOk("description"); ok {
 		datacenter.Properties.Description = attr.(string)
 	}
-	dc, err := client.CreateDatacenter(datacenter)
+
+	if d.Get("sec_auth_protection").(bool) {
+		secAuthProtection := true
+		datacenter.Properties.SecAuthProtection = &secAuthProtection
+	}
+
+	dc, err := createDatacenterWithExtras(client, datacenter)
 
 	if err != nil {
 		return fmt.Errorf(
@@ -64,7 +114,7 @@ func resourceProfitBricksDatacenterCreate(d *schema.ResourceData, meta interface
 	log.Printf("[INFO] DataCenter Id: %s", d.Id())
 
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, dc.Headers.Get("Location"), schema.TimeoutCreate).WaitForState()
+	_, errState := waitForRequest(meta, d, dc.Headers.Get("Location"), schema.TimeoutCreate)
 	if errState != nil {
 		if IsRequestFailed(err) {
 			// Request failed, so resource was not created, delete resource from state file
@@ -73,12 +123,16 @@ func resourceProfitBricksDatacenterCreate(d *schema.ResourceData, meta interface
 		return errState
 	}
 
+	if err := updateLabels(client, d, datacenterLabelsPath(d.Id())); err != nil {
+		return err
+	}
+
 	return resourceProfitBricksDatacenterRead(d, meta)
 }
 
 func resourceProfitBricksDatacenterRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*profitbricks.Client)
-	datacenter, err := client.GetDatacenter(d.Id())
+	datacenter, err := getDatacenterWithExtras(client, d.Id())
 
 	if err != nil {
 		if apiError, ok := err.(profitbricks.ApiError); ok {
@@ -87,18 +141,25 @@ func resourceProfitBricksDatacenterRead(d *schema.ResourceData, meta interface{}
 				return nil
 			}
 		}
-		return fmt.Errorf("Error while fetching a data center ID %s %s", d.Id(), err)
+		return fmt.Errorf("Error while fetching a data center ID %s %w", d.Id(), err)
 	}
 
 	d.Set("name", datacenter.Properties.Name)
 	d.Set("location", datacenter.Properties.Location)
 	d.Set("description", datacenter.Properties.Description)
+	if datacenter.Properties.SecAuthProtection != nil {
+		d.Set("sec_auth_protection", *datacenter.Properties.SecAuthProtection)
+	}
+
+	if err := readLabels(client, d, datacenterLabelsPath(d.Id())); err != nil {
+		return err
+	}
 	return nil
 }
 
 func resourceProfitBricksDatacenterUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*profitbricks.Client)
-	obj := profitbricks.DatacenterProperties{}
+	obj := datacenterPropertiesWithExtras{}
 
 	if d.HasChange("name") {
 		_, newName := d.GetChange("name")
@@ -111,21 +172,28 @@ func resourceProfitBricksDatacenterUpdate(d *schema.ResourceData, meta interface
 		obj.Description = newDescription.(string)
 	}
 
-	if d.HasChange("location") {
-		oldLocation, newLocation := d.GetChange("location")
-		return fmt.Errorf("Data center is created in %s location. You can not change location of the data center to %s. It requires recreation of the data center.", oldLocation, newLocation)
+	if d.HasChange("sec_auth_protection") {
+		_, newSecAuthProtection := d.GetChange("sec_auth_protection")
+		secAuthProtection := newSecAuthProtection.(bool)
+		obj.SecAuthProtection = &secAuthProtection
 	}
 
-	dc, err := client.UpdateDataCenter(d.Id(), obj)
+	if d.HasChanges("name", "description", "sec_auth_protection") {
+		dc, err := updateDatacenterWithExtras(client, d.Id(), obj)
 
-	if err != nil {
-		return fmt.Errorf("An error occured while update the data center ID %s %s", d.Id(), err)
+		if err != nil {
+			return fmt.Errorf("An error occured while update the data center ID %s %w", d.Id(), err)
+		}
+
+		// Wait, catching any errors
+		_, errState := waitForRequest(meta, d, dc.Headers.Get("Location"), schema.TimeoutUpdate)
+		if errState != nil {
+			return errState
+		}
 	}
 
-	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, dc.Headers.Get("Location"), schema.TimeoutUpdate).WaitForState()
-	if errState != nil {
-		return errState
+	if err := updateLabels(client, d, datacenterLabelsPath(d.Id())); err != nil {
+		return err
 	}
 
 	return resourceProfitBricksDatacenterRead(d, meta)
@@ -137,11 +205,15 @@ func resourceProfitBricksDatacenterDelete(d *schema.ResourceData, meta interface
 	resp, err := client.DeleteDatacenter(dcid)
 
 	if err != nil {
-		return fmt.Errorf("An error occured while deleting the data center ID %s %s", d.Id(), err)
+		if apiError, ok := err.(profitbricks.ApiError); ok && d.Get("sec_auth_protection").(bool) &&
+			(apiError.HttpStatusCode() == 401 || apiError.HttpStatusCode() == 403) {
+			return fmt.Errorf("The data center ID %s is protected by secure authentication, set sec_auth_protection to false and apply before deleting it: %w", d.Id(), err)
+		}
+		return fmt.Errorf("An error occured while deleting the data center ID %s %w", d.Id(), err)
 	}
 
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, resp.Get("Location"), schema.TimeoutDelete).WaitForState()
+	_, errState := waitForRequest(meta, d, resp.Get("Location"), schema.TimeoutDelete)
 	if errState != nil {
 		return errState
 	}
@@ -150,6 +222,36 @@ func resourceProfitBricksDatacenterDelete(d *schema.ResourceData, meta interface
 	return nil
 }
 
+// datacenterPropertiesWithExtras adds the data center properties which are not modeled by profitbricks-sdk-go yet
+type datacenterPropertiesWithExtras struct {
+	profitbricks.DatacenterProperties
+	SecAuthProtection *bool `json:"secAuthProtection,omitempty"`
+}
+
+type datacenterWithExtras struct {
+	ID         string                         `json:"id,omitempty"`
+	Properties datacenterPropertiesWithExtras `json:"properties"`
+	Headers    *http.Header                   `json:"headers,omitempty"`
+}
+
+func createDatacenterWithExtras(client *profitbricks.Client, datacenter datacenterWithExtras) (*datacenterWithExtras, error) {
+	ret := &datacenterWithExtras{}
+	err := client.Post("/datacenters", datacenter, ret, http.StatusAccepted)
+	return ret, err
+}
+
+func getDatacenterWithExtras(client *profitbricks.Client, dcId string) (*datacenterWithExtras, error) {
+	ret := &datacenterWithExtras{}
+	err := client.Get(fmt.Sprintf("/datacenters/%s", dcId), ret, http.StatusOK)
+	return ret, err
+}
+
+func updateDatacenterWithExtras(client *profitbricks.Client, dcId string, properties datacenterPropertiesWithExtras) (*datacenterWithExtras, error) {
+	ret := &datacenterWithExtras{}
+	err := client.Patch(fmt.Sprintf("/datacenters/%s", dcId), properties, ret, http.StatusAccepted)
+	return ret, err
+}
+
 func getImage(client *profitbricks.Client, dcId string, imageName string, imageType string) (*profitbricks.Image, error) {
 	if imageName == "" {
 		return nil, fmt.Errorf("imageName not suplied")
@@ -160,7 +262,7 @@ func getImage(client *profitbricks.Client, dcId string, imageName string, imageT
 		return nil, err
 	}
 
-	images, err := client.ListImages()
+	images, err := listImages(client)
 	if err != nil {
 		log.Print(fmt.Errorf("Error while fetching the list of images %s", err))
 		return nil, err
@@ -218,9 +320,10 @@ func getImageAlias(client *profitbricks.Client, imageAlias string, location stri
 	if imageAlias == "" {
 		return ""
 	}
-	locations, err := client.GetLocation(location)
+	locations, err := getLocationWithExtras(client, location)
 	if err != nil {
-		log.Print(fmt.Errorf("Error while fetching the list of snapshots %s", err))
+		log.Print(fmt.Errorf("Error while fetching the location %s %s", location, err))
+		return ""
 	}
 
 	if len(locations.Properties.ImageAliases) > 0 {
@@ -2,6 +2,7 @@ package profitbricks
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
@@ -25,6 +26,7 @@ func TestAccProfitBricksDataCenter_Basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckProfitBricksDatacenterExists("profitbricks_datacenter.foobar", &datacenter),
 					resource.TestCheckResourceAttr("profitbricks_datacenter.foobar", "name", dc_name),
+					resource.TestCheckResourceAttr("profitbricks_datacenter.foobar", "sec_auth_protection", "false"),
 				),
 			},
 			{
@@ -38,6 +40,104 @@ func TestAccProfitBricksDataCenter_Basic(t *testing.T) {
 	})
 }
 
+func TestAccProfitBricksDataCenter_Rename(t *testing.T) {
+	var datacenterId string
+	resourceName := "profitbricks_datacenter.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksDatacenterDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckProfitBricksDatacenterConfig_basic, "datacenter-rename-test"),
+				Check: func(s *terraform.State) error {
+					datacenterId = s.RootModule().Resources[resourceName].Primary.ID
+					return nil
+				},
+			},
+			{
+				// a name only change updates the data center in place
+				Config: fmt.Sprintf(testAccCheckProfitBricksDatacenterConfig_basic, "renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(resourceName, "id", &datacenterId),
+					resource.TestCheckResourceAttr(resourceName, "name", "renamed"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProfitBricksDataCenter_UniqueName(t *testing.T) {
+	var datacenter profitbricks.Datacenter
+	dc_name := "datacenter-unique-test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksDatacenterDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckProfitBricksDatacenterConfig_unique, dc_name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksDatacenterExists("profitbricks_datacenter.foobar", &datacenter),
+				),
+			},
+			{
+				Config:      fmt.Sprintf(testAccCheckProfitBricksDatacenterConfig_duplicate, dc_name, dc_name),
+				ExpectError: regexp.MustCompile("already exists in us/las"),
+			},
+		},
+	})
+}
+
+func TestAccProfitBricksDataCenter_Labels(t *testing.T) {
+	var datacenter profitbricks.Datacenter
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksDatacenterDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckProfitBricksDatacenterConfig_labels, `
+		cost_center = "1234"
+		team        = "infra"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksDatacenterExists("profitbricks_datacenter.foobar", &datacenter),
+					resource.TestCheckResourceAttr("profitbricks_datacenter.foobar", "labels.%", "2"),
+					resource.TestCheckResourceAttr("profitbricks_datacenter.foobar", "labels.cost_center", "1234"),
+					resource.TestCheckResourceAttr("profitbricks_datacenter.foobar", "labels.team", "infra"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckProfitBricksDatacenterConfig_labels, `
+		cost_center = "5678"
+		env         = "test"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("profitbricks_datacenter.foobar", "labels.%", "2"),
+					resource.TestCheckResourceAttr("profitbricks_datacenter.foobar", "labels.cost_center", "5678"),
+					resource.TestCheckResourceAttr("profitbricks_datacenter.foobar", "labels.env", "test"),
+					resource.TestCheckNoResourceAttr("profitbricks_datacenter.foobar", "labels.team"),
+				),
+			},
+			{
+				// without the labels attribute the labels of the data center are left alone
+				Config: fmt.Sprintf(testAccCheckProfitBricksDatacenterConfig_basic, "datacenter-labels-test"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("profitbricks_datacenter.foobar", "labels.%", "2"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckDProfitBricksDatacenterDestroyCheck(s *terraform.State) error {
 	client := testAccProvider.Meta().(*profitbricks.Client)
 	for _, rs := range s.RootModule().Resources {
@@ -97,3 +197,36 @@ resource "profitbricks_datacenter" "foobar" {
 	name       =  "updated"
 	location = "us/las"
 }`
+
+const testAccCheckProfitBricksDatacenterConfig_unique = `
+provider "profitbricks" {
+	unique_datacenter_names = true
+}
+
+resource "profitbricks_datacenter" "foobar" {
+	name       = "%s"
+	location = "us/las"
+}`
+
+const testAccCheckProfitBricksDatacenterConfig_duplicate = `
+provider "profitbricks" {
+	unique_datacenter_names = true
+}
+
+resource "profitbricks_datacenter" "foobar" {
+	name       = "%s"
+	location = "us/las"
+}
+
+resource "profitbricks_datacenter" "duplicate" {
+	name       = "%s"
+	location = "us/las"
+}`
+
+const testAccCheckProfitBricksDatacenterConfig_labels = `
+resource "profitbricks_datacenter" "foobar" {
+	name       = "datacenter-labels-test"
+	location = "us/las"
+	labels = {%s
+	}
+}`
@@ -2,7 +2,9 @@ package profitbricks
 
 import (
 	"fmt"
+	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
@@ -17,6 +19,7 @@ func resourceProfitBricksFirewall() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceProfitBricksFirewallImport,
 		},
+		CustomizeDiff: resourceProfitBricksFirewallCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 
 			"name": {
@@ -28,23 +31,38 @@ func resourceProfitBricksFirewall() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"type": {
+				Type:        schema.TypeString,
+				Description: "The direction of the traffic the rule applies to, either INGRESS or EGRESS",
+				Optional:    true,
+				Default:     "INGRESS",
+				ForceNew:    true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if v.(string) != "INGRESS" && v.(string) != "EGRESS" {
+						errors = append(errors, fmt.Errorf("%q must be either INGRESS or EGRESS", k))
+					}
+					return
+				},
+			},
 			"source_mac": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
 			"source_ip": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateIPv4Address,
 			},
 			"target_ip": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateIPv4Address,
 			},
 			"port_range_start": {
 				Type:     schema.TypeInt,
 				Optional: true,
 				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
-					if v.(int) < 1 && v.(int) > 65534 {
+					if v.(int) < 1 || v.(int) > 65534 {
 						errors = append(errors, fmt.Errorf("Port start range must be between 1 and 65534"))
 					}
 					return
@@ -55,7 +73,7 @@ func resourceProfitBricksFirewall() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
-					if v.(int) < 1 && v.(int) > 65534 {
+					if v.(int) < 1 || v.(int) > 65534 {
 						errors = append(errors, fmt.Errorf("Port end range must be between 1 and 65534"))
 					}
 					return
@@ -89,11 +107,41 @@ func resourceProfitBricksFirewall() *schema.Resource {
 	}
 }
 
+// resourceProfitBricksFirewallCustomizeDiff rejects combinations of protocol, ports and icmp settings the API refuses
+func resourceProfitBricksFirewallCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	protocol := strings.ToUpper(diff.Get("protocol").(string))
+
+	if protocol == "ICMP" {
+		for _, attr := range []string{"port_range_start", "port_range_end"} {
+			if _, ok := diff.GetOk(attr); ok {
+				return fmt.Errorf("'%s' cannot be set on a firewall rule with protocol ICMP", attr)
+			}
+		}
+	} else {
+		for _, attr := range []string{"icmp_type", "icmp_code"} {
+			if _, ok := diff.GetOk(attr); ok {
+				return fmt.Errorf("'%s' can only be set on a firewall rule with protocol ICMP, not %s", attr, protocol)
+			}
+		}
+	}
+
+	portRangeStart, startOk := diff.GetOk("port_range_start")
+	portRangeEnd, endOk := diff.GetOk("port_range_end")
+	if startOk && endOk && portRangeStart.(int) > portRangeEnd.(int) {
+		return fmt.Errorf("'port_range_start' (%d) must not be greater than 'port_range_end' (%d)", portRangeStart.(int), portRangeEnd.(int))
+	}
+
+	return nil
+}
+
 func resourceProfitBricksFirewallCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*profitbricks.Client)
-	fw := &profitbricks.FirewallRule{
-		Properties: profitbricks.FirewallruleProperties{
-			Protocol: d.Get("protocol").(string),
+	fw := &firewallRuleWithExtras{
+		Properties: firewallRulePropertiesWithExtras{
+			FirewallruleProperties: profitbricks.FirewallruleProperties{
+				Protocol: d.Get("protocol").(string),
+			},
+			Type: d.Get("type").(string),
 		},
 	}
 
@@ -123,27 +171,27 @@ func resourceProfitBricksFirewallCreate(d *schema.ResourceData, meta interface{}
 	if _, ok := d.GetOk("icmp_type"); ok {
 		tempIcmpType, err := strconv.Atoi(d.Get("icmp_type").(string))
 		if err != nil {
-			return fmt.Errorf("An error occured while creating a firewall rule: %s", err)
+			return fmt.Errorf("An error occured while creating a firewall rule: %w", err)
 		}
 		fw.Properties.IcmpType = &tempIcmpType
 	}
 	if _, ok := d.GetOk("icmp_code"); ok {
 		tempIcmpCodee, err := strconv.Atoi(d.Get("icmp_code").(string))
 		if err != nil {
-			return fmt.Errorf("An error occured while creating a firewall rule: %s", err)
+			return fmt.Errorf("An error occured while creating a firewall rule: %w", err)
 		}
 		fw.Properties.IcmpCode = &tempIcmpCodee
 	}
 
-	fw, err := client.CreateFirewallRule(d.Get("datacenter_id").(string), d.Get("server_id").(string), d.Get("nic_id").(string), *fw)
+	fw, err := createFirewallRuleWithExtras(client, d.Get("datacenter_id").(string), d.Get("server_id").(string), d.Get("nic_id").(string), *fw)
 
 	if err != nil {
-		return fmt.Errorf("An error occured while creating a firewall rule: %s", err)
+		return fmt.Errorf("An error occured while creating a firewall rule: %w", err)
 	}
 	d.SetId(fw.ID)
 
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, fw.Headers.Get("Location"), schema.TimeoutCreate).WaitForState()
+	_, errState := waitForRequest(meta, d, fw.Headers.Get("Location"), schema.TimeoutCreate)
 	if errState != nil {
 		if IsRequestFailed(err) {
 			// Request failed, so resource was not created, delete resource from state file
@@ -157,7 +205,7 @@ func resourceProfitBricksFirewallCreate(d *schema.ResourceData, meta interface{}
 
 func resourceProfitBricksFirewallRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*profitbricks.Client)
-	fw, err := client.GetFirewallRule(d.Get("datacenter_id").(string), d.Get("server_id").(string), d.Get("nic_id").(string), d.Id())
+	fw, err := getFirewallRuleWithExtras(client, d.Get("datacenter_id").(string), d.Get("server_id").(string), d.Get("nic_id").(string), d.Id())
 
 	if err != nil {
 		if apiError, ok := err.(profitbricks.ApiError); ok {
@@ -166,10 +214,16 @@ func resourceProfitBricksFirewallRead(d *schema.ResourceData, meta interface{})
 				return nil
 			}
 		}
-		return fmt.Errorf("An error occured while fetching a firewall rule  dcId: %s server_id: %s  nic_id: %s ID: %s %s", d.Get("datacenter_id").(string), d.Get("server_id").(string), d.Get("nic_id").(string), d.Id(), err)
+		return fmt.Errorf("An error occured while fetching a firewall rule  dcId: %s server_id: %s  nic_id: %s ID: %s %w", d.Get("datacenter_id").(string), d.Get("server_id").(string), d.Get("nic_id").(string), d.Id(), err)
 	}
 
 	d.Set("protocol", fw.Properties.Protocol)
+	// rules created before egress rules were supported do not report a type
+	if fw.Properties.Type != "" {
+		d.Set("type", fw.Properties.Type)
+	} else {
+		d.Set("type", "INGRESS")
+	}
 	d.Set("name", fw.Properties.Name)
 	d.Set("source_mac", fw.Properties.SourceMac)
 	d.Set("source_ip", fw.Properties.SourceIP)
@@ -193,29 +247,34 @@ func resourceProfitBricksFirewallUpdate(d *schema.ResourceData, meta interface{}
 	}
 	if d.HasChange("source_mac") {
 		_, new := d.GetChange("source_mac")
-		properties.SourceMac = new.(*string)
+		value := new.(string)
+		properties.SourceMac = &value
 	}
 	if d.HasChange("source_ip") {
 		_, new := d.GetChange("source_ip")
-		properties.SourceIP = new.(*string)
+		value := new.(string)
+		properties.SourceIP = &value
 	}
 	if d.HasChange("target_ip") {
 		_, new := d.GetChange("target_ip")
-		properties.TargetIP = new.(*string)
+		value := new.(string)
+		properties.TargetIP = &value
 	}
 	if d.HasChange("port_range_start") {
 		_, new := d.GetChange("port_range_start")
-		properties.PortRangeStart = new.(*int)
+		value := new.(int)
+		properties.PortRangeStart = &value
 	}
 	if d.HasChange("port_range_end") {
 		_, new := d.GetChange("port_range_end")
-		properties.PortRangeEnd = new.(*int)
+		value := new.(int)
+		properties.PortRangeEnd = &value
 	}
 	if d.HasChange("icmp_type") {
 		_, new := d.GetChange("icmp_type")
 		tempIcmpType, err := strconv.Atoi(new.(string))
 		if err != nil {
-			return fmt.Errorf("An error occured while updating a firewall rule: %s", err)
+			return fmt.Errorf("An error occured while updating a firewall rule: %w", err)
 		}
 		properties.IcmpType = &tempIcmpType
 	}
@@ -223,7 +282,7 @@ func resourceProfitBricksFirewallUpdate(d *schema.ResourceData, meta interface{}
 		_, new := d.GetChange("icmp_code")
 		tempIcmpCode, err := strconv.Atoi(new.(string))
 		if err != nil {
-			return fmt.Errorf("An error occured while updating a firewall rule: %s", err)
+			return fmt.Errorf("An error occured while updating a firewall rule: %w", err)
 		}
 		properties.IcmpCode = &tempIcmpCode
 	}
@@ -231,11 +290,11 @@ func resourceProfitBricksFirewallUpdate(d *schema.ResourceData, meta interface{}
 	fw, err := client.UpdateFirewallRule(d.Get("datacenter_id").(string), d.Get("server_id").(string), d.Get("nic_id").(string), d.Id(), properties)
 
 	if err != nil {
-		return fmt.Errorf("An error occured while updating a firewall rule ID %s %s", d.Id(), err)
+		return fmt.Errorf("An error occured while updating a firewall rule ID %s %w", d.Id(), err)
 	}
 
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, fw.Headers.Get("Location"), schema.TimeoutUpdate).WaitForState()
+	_, errState := waitForRequest(meta, d, fw.Headers.Get("Location"), schema.TimeoutUpdate)
 	if errState != nil {
 		return errState
 	}
@@ -248,11 +307,11 @@ func resourceProfitBricksFirewallDelete(d *schema.ResourceData, meta interface{}
 	resp, err := client.DeleteFirewallRule(d.Get("datacenter_id").(string), d.Get("server_id").(string), d.Get("nic_id").(string), d.Id())
 
 	if err != nil {
-		return fmt.Errorf("An error occured while deleting a firewall rule ID %s %s", d.Id(), err)
+		return fmt.Errorf("An error occured while deleting a firewall rule ID %s %w", d.Id(), err)
 	}
 
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, resp.Get("Location"), schema.TimeoutDelete).WaitForState()
+	_, errState := waitForRequest(meta, d, resp.Get("Location"), schema.TimeoutDelete)
 	if errState != nil {
 		return errState
 	}
@@ -261,3 +320,27 @@ func resourceProfitBricksFirewallDelete(d *schema.ResourceData, meta interface{}
 
 	return nil
 }
+
+// firewallRulePropertiesWithExtras adds the firewall rule properties which are not modeled by profitbricks-sdk-go yet
+type firewallRulePropertiesWithExtras struct {
+	profitbricks.FirewallruleProperties
+	Type string `json:"type,omitempty"`
+}
+
+type firewallRuleWithExtras struct {
+	ID         string                           `json:"id,omitempty"`
+	Properties firewallRulePropertiesWithExtras `json:"properties"`
+	Headers    *http.Header                     `json:"headers,omitempty"`
+}
+
+func createFirewallRuleWithExtras(client *profitbricks.Client, dcId string, serverId string, nicId string, fw firewallRuleWithExtras) (*firewallRuleWithExtras, error) {
+	ret := &firewallRuleWithExtras{}
+	err := client.Post(fmt.Sprintf("/datacenters/%s/servers/%s/nics/%s/firewallrules", dcId, serverId, nicId), fw, ret, http.StatusAccepted)
+	return ret, err
+}
+
+func getFirewallRuleWithExtras(client *profitbricks.Client, dcId string, serverId string, nicId string, fwId string) (*firewallRuleWithExtras, error) {
+	ret := &firewallRuleWithExtras{}
+	err := client.Get(fmt.Sprintf("/datacenters/%s/servers/%s/nics/%s/firewallrules/%s", dcId, serverId, nicId, fwId), ret, http.StatusOK)
+	return ret, err
+}
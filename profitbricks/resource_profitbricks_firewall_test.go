@@ -2,6 +2,7 @@ package profitbricks
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
@@ -26,6 +27,7 @@ func TestAccProfitBricksFirewall_Basic(t *testing.T) {
 					testAccCheckProfitBricksFirewallExists("profitbricks_firewall.webserver_http", &firewall),
 					testAccCheckProfitBricksFirewallAttributes("profitbricks_firewall.webserver_http", firewallName),
 					resource.TestCheckResourceAttr("profitbricks_firewall.webserver_http", "name", firewallName),
+					resource.TestCheckResourceAttr("profitbricks_firewall.webserver_http", "type", "INGRESS"),
 				),
 			},
 			{
@@ -102,6 +104,46 @@ func testAccCheckProfitBricksFirewallExists(n string, firewall *profitbricks.Fir
 	}
 }
 
+func TestAccProfitBricksFirewall_Validation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccCheckProfitbricksFirewallConfig_validation, "ICMP", "port_range_start = 22"),
+				ExpectError: regexp.MustCompile(`'port_range_start' cannot be set on a firewall rule with protocol ICMP`),
+			},
+			{
+				Config:      fmt.Sprintf(testAccCheckProfitbricksFirewallConfig_validation, "TCP", `icmp_type = "8"`),
+				ExpectError: regexp.MustCompile(`'icmp_type' can only be set on a firewall rule with protocol ICMP`),
+			},
+			{
+				Config:      fmt.Sprintf(testAccCheckProfitbricksFirewallConfig_validation, "TCP", "port_range_start = 80\n  port_range_end = 22"),
+				ExpectError: regexp.MustCompile(`'port_range_start' \(80\) must not be greater than 'port_range_end' \(22\)`),
+			},
+			{
+				Config:      fmt.Sprintf(testAccCheckProfitbricksFirewallConfig_validation, "TCP", `type = "OUTBOUND"`),
+				ExpectError: regexp.MustCompile(`"type" must be either INGRESS or EGRESS`),
+			},
+			{
+				Config:      fmt.Sprintf(testAccCheckProfitbricksFirewallConfig_validation, "TCP", `source_ip = "2001:db8::1"`),
+				ExpectError: regexp.MustCompile(`"source_ip" must be an IPv4 address, got the IPv6 address "2001:db8::1"`),
+			},
+		},
+	})
+}
+
+const testAccCheckProfitbricksFirewallConfig_validation = `
+resource "profitbricks_firewall" "invalid" {
+  datacenter_id = "datacenterId"
+  server_id = "serverId"
+  nic_id = "nicId"
+  protocol = "%s"
+  %s
+}`
+
 const testAccCheckProfitbricksFirewallConfig_basic = `
 resource "profitbricks_datacenter" "foobar" {
 	name       = "firewall-test"
@@ -104,12 +104,12 @@ func resourceProfitBricksGroupCreate(d *schema.ResourceData, meta interface{}) e
 	log.Printf("[DEBUG] GROUP ID: %s", group.ID)
 
 	if err != nil {
-		return fmt.Errorf("An error occured while creating a group: %s", err)
+		return fmt.Errorf("An error occured while creating a group: %w", err)
 	}
 	d.SetId(group.ID)
 
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, group.Headers.Get("Location"), schema.TimeoutCreate).WaitForState()
+	_, errState := waitForRequest(meta, d, group.Headers.Get("Location"), schema.TimeoutCreate)
 	if errState != nil {
 		if IsRequestFailed(err) {
 			// Request failed, so resource was not created, delete resource from state file
@@ -122,10 +122,10 @@ func resourceProfitBricksGroupCreate(d *schema.ResourceData, meta interface{}) e
 	if usertoAdd != "" {
 		addedUser, err := client.AddUserToGroup(d.Id(), usertoAdd)
 		if err != nil {
-			return fmt.Errorf("An error occured while adding %s user to group ID %s %s", usertoAdd, d.Id(), err)
+			return fmt.Errorf("An error occured while adding %s user to group ID %s %w", usertoAdd, d.Id(), err)
 		}
 		// Wait, catching any errors
-		_, errState := getStateChangeConf(meta, d, addedUser.Headers.Get("Location"), schema.TimeoutCreate).WaitForState()
+		_, errState := waitForRequest(meta, d, addedUser.Headers.Get("Location"), schema.TimeoutCreate)
 		if errState != nil {
 			return errState
 		}
@@ -144,7 +144,7 @@ func resourceProfitBricksGroupRead(d *schema.ResourceData, meta interface{}) err
 				return nil
 			}
 		}
-		return fmt.Errorf("An error occured while fetching a Group ID %s %s", d.Id(), err)
+		return fmt.Errorf("An error occured while fetching a Group ID %s %w", d.Id(), err)
 	}
 
 	d.Set("name", group.Properties.Name)
@@ -155,7 +155,7 @@ func resourceProfitBricksGroupRead(d *schema.ResourceData, meta interface{}) err
 
 	users, err := client.ListGroupUsers(d.Id())
 	if err != nil {
-		return fmt.Errorf("An error occured while ListGroupUsers %s %s", d.Id(), err)
+		return fmt.Errorf("An error occured while ListGroupUsers %s %w", d.Id(), err)
 	}
 
 	var usersArray = []profitbricks.UserProperties{}
@@ -190,23 +190,23 @@ func resourceProfitBricksGroupUpdate(d *schema.ResourceData, meta interface{}) e
 
 	group, err := client.UpdateGroup(d.Id(), groupReq)
 	if err != nil {
-		return fmt.Errorf("An error occured while patching a group ID %s %s", d.Id(), err)
+		return fmt.Errorf("An error occured while patching a group ID %s %w", d.Id(), err)
 	}
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, group.Headers.Get("Location"), schema.TimeoutUpdate).WaitForState()
+	_, errState := waitForRequest(meta, d, group.Headers.Get("Location"), schema.TimeoutUpdate)
 	if errState != nil {
 		return errState
 	}
 
-	//add users to group if any is provided
-	if usertoAdd != "" {
+	//add the user to the group if it changed, members added by other means are left alone
+	if usertoAdd != "" && d.HasChange("user_id") {
 		addedUser, err := client.AddUserToGroup(d.Id(), usertoAdd)
 		if err != nil {
-			return fmt.Errorf("An error occured while adding %s user to group ID %s %s", usertoAdd, d.Id(), err)
+			return fmt.Errorf("An error occured while adding %s user to group ID %s %w", usertoAdd, d.Id(), err)
 		}
 
 		// Wait, catching any errors
-		_, errState := getStateChangeConf(meta, d, addedUser.Headers.Get("Location"), schema.TimeoutCreate).WaitForState()
+		_, errState := waitForRequest(meta, d, addedUser.Headers.Get("Location"), schema.TimeoutCreate)
 		if errState != nil {
 			return errState
 		}
@@ -225,14 +225,14 @@ func resourceProfitBricksGroupDelete(d *schema.ResourceData, meta interface{}) e
 		if err != nil {
 			if apiError, ok := err.(profitbricks.ApiError); ok {
 				if apiError.HttpStatusCode() != 404 {
-					return fmt.Errorf("An error occured while deleting a group %s %s", d.Id(), err)
+					return fmt.Errorf("An error occured while deleting a group %s %w", d.Id(), err)
 				}
 			}
 		}
 	}
 
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, resp.Get("Location"), schema.TimeoutDelete).WaitForState()
+	_, errState := waitForRequest(meta, d, resp.Get("Location"), schema.TimeoutDelete)
 	if errState != nil {
 		return errState
 	}
@@ -0,0 +1,111 @@
+package profitbricks
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
+)
+
+func resourceProfitBricksGroupMembership() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceProfitBricksGroupMembershipCreate,
+		Read:   resourceProfitBricksGroupMembershipRead,
+		Delete: resourceProfitBricksGroupMembershipDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceProfitBricksGroupMembershipImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+		Timeouts: &resourceDefaultTimeouts,
+	}
+}
+
+func resourceProfitBricksGroupMembershipCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+	groupId := d.Get("group_id").(string)
+	userId := d.Get("user_id").(string)
+
+	addedUser, err := client.AddUserToGroup(groupId, userId)
+	if err != nil {
+		return fmt.Errorf("An error occured while adding user %s to group ID %s %w", userId, groupId, err)
+	}
+	d.SetId(fmt.Sprintf("%s/%s", groupId, userId))
+	log.Printf("[INFO] Added user %s to group %s", userId, groupId)
+
+	// Wait, catching any errors
+	_, errState := waitForRequest(meta, d, addedUser.Headers.Get("Location"), schema.TimeoutCreate)
+	if errState != nil {
+		if IsRequestFailed(errState) {
+			// Request failed, so the user was not added, delete resource from state file
+			d.SetId("")
+		}
+		return errState
+	}
+
+	return resourceProfitBricksGroupMembershipRead(d, meta)
+}
+
+func resourceProfitBricksGroupMembershipRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+	groupId := d.Get("group_id").(string)
+	userId := d.Get("user_id").(string)
+
+	users, err := client.ListGroupUsers(groupId)
+	if err != nil {
+		if apiError, ok := err.(profitbricks.ApiError); ok {
+			if apiError.HttpStatusCode() == 404 {
+				d.SetId("")
+				return nil
+			}
+		}
+		return fmt.Errorf("An error occured while fetching the users of group ID %s %w", groupId, err)
+	}
+
+	for _, user := range users.Items {
+		if user.ID == userId {
+			return nil
+		}
+	}
+
+	log.Printf("[INFO] User %s is no longer a member of group %s", userId, groupId)
+	d.SetId("")
+	return nil
+}
+
+func resourceProfitBricksGroupMembershipDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+	groupId := d.Get("group_id").(string)
+	userId := d.Get("user_id").(string)
+
+	resp, err := client.DeleteUserFromGroup(groupId, userId)
+	if err != nil {
+		if apiError, ok := err.(profitbricks.ApiError); ok {
+			if apiError.HttpStatusCode() == 404 {
+				d.SetId("")
+				return nil
+			}
+		}
+		return fmt.Errorf("An error occured while removing user %s from group ID %s %w", userId, groupId, err)
+	}
+
+	// Wait, catching any errors
+	_, errState := waitForRequest(meta, d, resp.Get("Location"), schema.TimeoutDelete)
+	if errState != nil {
+		return errState
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,117 @@
+package profitbricks
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
+)
+
+func TestAccProfitBricksGroupMembership_Basic(t *testing.T) {
+	r1 := rand.New(rand.NewSource(time.Now().UnixNano()))
+	email := strconv.Itoa(r1.Intn(100000)) + "terraform_test" + strconv.Itoa(r1.Intn(100000)) + "@go.com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckProfitBricksGroupMembershipDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckProfitbricksGroupMembershipConfig_basic, email),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksGroupMembershipExists("profitbricks_group_membership.membership"),
+					resource.TestCheckResourceAttrPair("profitbricks_group_membership.membership", "group_id", "profitbricks_group.group", "id"),
+					resource.TestCheckResourceAttrPair("profitbricks_group_membership.membership", "user_id", "profitbricks_user.user", "id"),
+				),
+			},
+			{
+				ResourceName:      "profitbricks_group_membership.membership",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckProfitBricksGroupMembershipDestroyCheck(s *terraform.State) error {
+	client := testAccProvider.Meta().(*profitbricks.Client)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "profitbricks_group_membership" {
+			continue
+		}
+
+		users, err := client.ListGroupUsers(rs.Primary.Attributes["group_id"])
+		if err != nil {
+			if apiError, ok := err.(profitbricks.ApiError); ok && apiError.HttpStatusCode() == 404 {
+				continue
+			}
+			return fmt.Errorf("Unable to fetch the users of group %s %s", rs.Primary.Attributes["group_id"], err)
+		}
+
+		for _, user := range users.Items {
+			if user.ID == rs.Primary.Attributes["user_id"] {
+				return fmt.Errorf("user %s is still a member of group %s", user.ID, rs.Primary.Attributes["group_id"])
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckProfitBricksGroupMembershipExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*profitbricks.Client)
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		users, err := client.ListGroupUsers(rs.Primary.Attributes["group_id"])
+		if err != nil {
+			return fmt.Errorf("Error occured while fetching the users of group: %s", rs.Primary.Attributes["group_id"])
+		}
+
+		for _, user := range users.Items {
+			if user.ID == rs.Primary.Attributes["user_id"] {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("Record not found")
+	}
+}
+
+const testAccCheckProfitbricksGroupMembershipConfig_basic = `
+resource "profitbricks_user" "user" {
+  first_name = "terraform"
+  last_name = "test"
+  email = "%s"
+  password = "abc123-321CBA"
+  administrator = false
+  force_sec_auth= false
+}
+
+resource "profitbricks_group" "group" {
+  name = "terraform membership group"
+  create_datacenter = false
+  create_snapshot = false
+  reserve_ip = false
+  access_activity_log = false
+}
+
+resource "profitbricks_group_membership" "membership" {
+  group_id = "${profitbricks_group.group.id}"
+  user_id = "${profitbricks_user.user.id}"
+}`
@@ -0,0 +1,231 @@
+package profitbricks
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
+)
+
+// imageHotPlugProperties maps the capability flags of an image to their API property names
+var imageHotPlugProperties = map[string]string{
+	"cpu_hot_plug":           "cpuHotPlug",
+	"cpu_hot_unplug":         "cpuHotUnplug",
+	"ram_hot_plug":           "ramHotPlug",
+	"ram_hot_unplug":         "ramHotUnplug",
+	"nic_hot_plug":           "nicHotPlug",
+	"nic_hot_unplug":         "nicHotUnplug",
+	"disc_virtio_hot_plug":   "discVirtioHotPlug",
+	"disc_virtio_hot_unplug": "discVirtioHotUnplug",
+	"disc_scsi_hot_plug":     "discScsiHotPlug",
+	"disc_scsi_hot_unplug":   "discScsiHotUnplug",
+}
+
+func resourceProfitBricksImage() *schema.Resource {
+	imageSchema := map[string]*schema.Schema{
+		"image_id": {
+			Type:        schema.TypeString,
+			Description: "The ID of an already uploaded private image to manage",
+			Required:    true,
+			ForceNew:    true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"licence_type": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"cloud_init": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+			ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+				if v.(string) != "NONE" && v.(string) != "V1" {
+					errors = append(errors, fmt.Errorf("%q must be either NONE or V1", k))
+				}
+				return
+			},
+		},
+		"location": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"size": {
+			Type:     schema.TypeFloat,
+			Computed: true,
+		},
+		"image_type": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+
+	return &schema.Resource{
+		Create: resourceProfitBricksImageCreate,
+		Read:   resourceProfitBricksImageRead,
+		Update: resourceProfitBricksImageUpdate,
+		Delete: resourceProfitBricksImageDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceProfitBricksImageImport,
+		},
+		Schema:   withHotPlugSchema(imageSchema, imageHotPlugProperties),
+		Timeouts: &resourceDefaultTimeouts,
+	}
+}
+
+func resourceProfitBricksImageCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+	imageId := d.Get("image_id").(string)
+
+	// images are uploaded via FTP, so creating one only means taking over an existing private image
+	image, err := getImageWithExtras(client, imageId)
+	if err != nil {
+		if apiError, ok := err.(profitbricks.ApiError); ok && apiError.HttpStatusCode() == 404 {
+			return fmt.Errorf("Image %s does not exist, images have to be uploaded via FTP before they can be managed", imageId)
+		}
+		return fmt.Errorf("Error while fetching image %s: %w", imageId, err)
+	}
+
+	if image.Properties.Public {
+		return fmt.Errorf("Image %s is a public image, only private images can be managed", imageId)
+	}
+
+	d.SetId(image.ID)
+	log.Printf("[INFO] Managing image %s", d.Id())
+
+	return resourceProfitBricksImageUpdate(d, meta)
+}
+
+func resourceProfitBricksImageRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+	image, err := getImageWithExtras(client, d.Id())
+
+	if err != nil {
+		if apiError, ok := err.(profitbricks.ApiError); ok {
+			if apiError.HttpStatusCode() == 404 {
+				d.SetId("")
+				return nil
+			}
+		}
+		return fmt.Errorf("Error while fetching image %s: %w", d.Id(), err)
+	}
+
+	log.Printf("[INFO] Successfully retreived image %s: %+v", d.Id(), image)
+
+	d.Set("image_id", image.ID)
+	d.Set("name", image.Properties.Name)
+	d.Set("description", image.Properties.Description)
+	d.Set("licence_type", image.Properties.LicenceType)
+	d.Set("cloud_init", image.Properties.CloudInit)
+	d.Set("location", image.Properties.Location)
+	d.Set("size", image.Properties.Size)
+	d.Set("image_type", image.Properties.ImageType)
+	d.Set("cpu_hot_plug", image.Properties.CPUHotPlug)
+	d.Set("cpu_hot_unplug", image.Properties.CPUHotUnplug)
+	d.Set("ram_hot_plug", image.Properties.RAMHotPlug)
+	d.Set("ram_hot_unplug", image.Properties.RAMHotUnplug)
+	d.Set("nic_hot_plug", image.Properties.NicHotPlug)
+	d.Set("nic_hot_unplug", image.Properties.NicHotUnplug)
+	d.Set("disc_virtio_hot_plug", image.Properties.DiscVirtioHotPlug)
+	d.Set("disc_virtio_hot_unplug", image.Properties.DiscVirtioHotUnplug)
+	d.Set("disc_scsi_hot_plug", image.Properties.DiscScsiHotPlug)
+	d.Set("disc_scsi_hot_unplug", image.Properties.DiscScsiHotUnplug)
+
+	return nil
+}
+
+func resourceProfitBricksImageUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+	properties := changedProperties(d, map[string]string{
+		"name":         "name",
+		"description":  "description",
+		"licence_type": "licenceType",
+		"cloud_init":   "cloudInit",
+	})
+
+	// the hot plug flags are sent explicitly, the sdk model would drop flags which are turned off
+	for property, value := range changedProperties(d, imageHotPlugProperties) {
+		properties[property] = value
+	}
+
+	if len(properties) > 0 {
+		log.Printf("[INFO] Attempting to update image %s with %+v", d.Id(), properties)
+
+		resp := &profitbricks.Image{}
+		err := client.Patch(fmt.Sprintf("/images/%s", d.Id()), properties, resp, http.StatusAccepted)
+		if err != nil {
+			return fmt.Errorf("Error while updating image %s: %w", d.Id(), err)
+		}
+
+		timeoutType := schema.TimeoutUpdate
+		if d.IsNewResource() {
+			timeoutType = schema.TimeoutCreate
+		}
+
+		// Wait, catching any errors
+		_, errState := waitForRequest(meta, d, resp.Headers.Get("Location"), timeoutType)
+		if errState != nil {
+			return errState
+		}
+	}
+
+	return resourceProfitBricksImageRead(d, meta)
+}
+
+func resourceProfitBricksImageDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+
+	resp := &http.Header{}
+	err := client.Delete(fmt.Sprintf("/images/%s", d.Id()), resp, http.StatusAccepted)
+	if err != nil {
+		if apiError, ok := err.(profitbricks.ApiError); ok && apiError.HttpStatusCode() == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error while deleting image %s: %w", d.Id(), err)
+	}
+
+	// Wait, catching any errors
+	_, errState := waitForRequest(meta, d, resp.Get("Location"), schema.TimeoutDelete)
+	if errState != nil {
+		return errState
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceProfitBricksImageImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("image_id", d.Id())
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// imagePropertiesWithExtras adds the image properties which are not modeled by profitbricks-sdk-go yet
+type imagePropertiesWithExtras struct {
+	profitbricks.ImageProperties
+	CloudInit string `json:"cloudInit,omitempty"`
+}
+
+type imageWithExtras struct {
+	ID         string                    `json:"id,omitempty"`
+	Properties imagePropertiesWithExtras `json:"properties"`
+	Headers    *http.Header              `json:"headers,omitempty"`
+}
+
+func getImageWithExtras(client *profitbricks.Client, imageId string) (*imageWithExtras, error) {
+	ret := &imageWithExtras{}
+	err := client.Get(fmt.Sprintf("/images/%s", imageId), ret, http.StatusOK)
+	return ret, err
+}
@@ -0,0 +1,100 @@
+package profitbricks
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
+)
+
+// images can only be uploaded via FTP, so this test needs an already uploaded private image which it deletes afterwards
+func TestAccProfitBricksImage_Basic(t *testing.T) {
+	imageId := os.Getenv("PROFITBRICKS_IMAGE_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if imageId == "" {
+				t.Skip("PROFITBRICKS_IMAGE_ID must be set to the id of an uploaded private image")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckProfitBricksImageDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckProfitBricksImageConfigBasic, imageId, "terraform image", "true"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksImageExists("profitbricks_image.example"),
+					resource.TestCheckResourceAttr("profitbricks_image.example", "name", "terraform image"),
+					resource.TestCheckResourceAttr("profitbricks_image.example", "cpu_hot_plug", "true"),
+					resource.TestCheckResourceAttrSet("profitbricks_image.example", "location"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckProfitBricksImageConfigBasic, imageId, "updated terraform image", "false"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksImageExists("profitbricks_image.example"),
+					resource.TestCheckResourceAttr("profitbricks_image.example", "name", "updated terraform image"),
+					resource.TestCheckResourceAttr("profitbricks_image.example", "cpu_hot_plug", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckProfitBricksImageDestroyCheck(s *terraform.State) error {
+	client := testAccProvider.Meta().(*profitbricks.Client)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "profitbricks_image" {
+			continue
+		}
+
+		_, err := client.GetImage(rs.Primary.ID)
+
+		if apiError, ok := err.(profitbricks.ApiError); ok {
+			if apiError.HttpStatusCode() != 404 {
+				return fmt.Errorf("image still exists %s %s", rs.Primary.ID, apiError)
+			}
+		} else {
+			return fmt.Errorf("Unable to fetch image %s %s", rs.Primary.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckProfitBricksImageExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*profitbricks.Client)
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		foundImage, err := client.GetImage(rs.Primary.ID)
+
+		if err != nil {
+			return fmt.Errorf("Error occured while fetching image: %s", rs.Primary.ID)
+		}
+		if foundImage.ID != rs.Primary.ID {
+			return fmt.Errorf("Record not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckProfitBricksImageConfigBasic = `
+resource "profitbricks_image" "example" {
+  image_id     = "%s"
+  name         = "%s"
+  cpu_hot_plug = %s
+}`
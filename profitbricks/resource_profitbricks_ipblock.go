@@ -1,8 +1,11 @@
 package profitbricks
 
 import (
+	"encoding/binary"
 	"fmt"
 	"log"
+	"net"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -16,7 +19,7 @@ func resourceProfitBricksIPBlock() *schema.Resource {
 		Update: resourceProfitBricksIPBlockUpdate,
 		Delete: resourceProfitBricksIPBlockDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceProfitBricksIPBlockImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -38,6 +41,13 @@ func resourceProfitBricksIPBlock() *schema.Resource {
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Computed: true,
 			},
+			"cidrs": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "The fewest CIDR blocks covering exactly the ips of the block, a single one when they are contiguous",
+			},
+			"ip_consumers": ipConsumersSchema(),
 		},
 		Timeouts: &resourceDefaultTimeouts,
 	}
@@ -56,12 +66,12 @@ func resourceProfitBricksIPBlockCreate(d *schema.ResourceData, meta interface{})
 	ipblock, err := client.ReserveIPBlock(*ipblock)
 
 	if err != nil {
-		return fmt.Errorf("An error occured while reserving an ip block: %s", err)
+		return fmt.Errorf("An error occured while reserving an ip block: %w", err)
 	}
 	d.SetId(ipblock.ID)
 
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, ipblock.Headers.Get("Location"), schema.TimeoutCreate).WaitForState()
+	_, errState := waitForRequest(meta, d, ipblock.Headers.Get("Location"), schema.TimeoutCreate)
 	if errState != nil {
 		if IsRequestFailed(err) {
 			// Request failed, so resource was not created, delete resource from state file
@@ -84,16 +94,21 @@ func resourceProfitBricksIPBlockRead(d *schema.ResourceData, meta interface{}) e
 				return nil
 			}
 		}
-		return fmt.Errorf("An error occured while fetching an ip block ID %s %s", d.Id(), err)
+		return fmt.Errorf("An error occured while fetching an ip block ID %s %w", d.Id(), err)
 	}
 
 	log.Printf("[INFO] IPS: %s", strings.Join(ipblock.Properties.IPs, ","))
 
 	d.Set("ips", ipblock.Properties.IPs)
+	d.Set("cidrs", ipv4CIDRs(ipblock.Properties.IPs))
 	d.Set("location", ipblock.Properties.Location)
 	d.Set("size", ipblock.Properties.Size)
 	d.Set("name", ipblock.Properties.Name)
 
+	if err := d.Set("ip_consumers", flattenIPConsumers(ipblock.Properties.IPConsumers)); err != nil {
+		return fmt.Errorf("Error while setting ip_consumers of ip block %s: %w", d.Id(), err)
+	}
+
 	return nil
 }
 func resourceProfitBricksIPBlockUpdate(d *schema.ResourceData, meta interface{}) error {
@@ -108,7 +123,7 @@ func resourceProfitBricksIPBlockUpdate(d *schema.ResourceData, meta interface{})
 	_, err := client.UpdateIPBlock(d.Id(), request)
 
 	if err != nil {
-		return fmt.Errorf("An error occured while updating an ip block ID %s %s", d.Id(), err)
+		return fmt.Errorf("An error occured while updating an ip block ID %s %w", d.Id(), err)
 	}
 
 	return nil
@@ -119,11 +134,11 @@ func resourceProfitBricksIPBlockDelete(d *schema.ResourceData, meta interface{})
 	client := meta.(*profitbricks.Client)
 	resp, err := client.ReleaseIPBlock(d.Id())
 	if err != nil {
-		return fmt.Errorf("An error occured while releasing an ipblock ID: %s %s", d.Id(), err)
+		return fmt.Errorf("An error occured while releasing an ipblock ID: %s %w", d.Id(), err)
 	}
 
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, resp.Get("Location"), schema.TimeoutDelete).WaitForState()
+	_, errState := waitForRequest(meta, d, resp.Get("Location"), schema.TimeoutDelete)
 	if errState != nil {
 		return errState
 	}
@@ -131,3 +146,46 @@ func resourceProfitBricksIPBlockDelete(d *schema.ResourceData, meta interface{})
 	d.SetId("")
 	return nil
 }
+
+// ipv4CIDRs summarizes IPv4 addresses into the fewest CIDR blocks containing exactly these addresses.
+// IP blocks are reserved by size, the API does not guarantee their addresses to be contiguous
+func ipv4CIDRs(ips []string) []string {
+	addresses := []uint64{}
+	seen := map[uint64]bool{}
+	for _, raw := range ips {
+		ip := net.ParseIP(raw).To4()
+		if ip == nil {
+			continue
+		}
+		address := uint64(binary.BigEndian.Uint32(ip))
+		if !seen[address] {
+			seen[address] = true
+			addresses = append(addresses, address)
+		}
+	}
+	sort.Slice(addresses, func(i, j int) bool { return addresses[i] < addresses[j] })
+
+	cidrs := []string{}
+	for i := 0; i < len(addresses); {
+		// the run of consecutive addresses starting at i
+		j := i
+		for j+1 < len(addresses) && addresses[j+1] == addresses[j]+1 {
+			j++
+		}
+
+		// split the run into the largest blocks aligned to their size
+		for start, end := addresses[i], addresses[j]; start <= end; {
+			bits := uint(0)
+			for bits < 32 && start%(1<<(bits+1)) == 0 && start+(1<<(bits+1))-1 <= end {
+				bits++
+			}
+			ip := make(net.IP, 4)
+			binary.BigEndian.PutUint32(ip, uint32(start))
+			cidrs = append(cidrs, fmt.Sprintf("%s/%d", ip, 32-bits))
+			start += 1 << bits
+		}
+
+		i = j + 1
+	}
+	return cidrs
+}
@@ -2,6 +2,7 @@ package profitbricks
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
@@ -26,6 +27,8 @@ func TestAccProfitBricksIPBlock_Basic(t *testing.T) {
 					testAccCheckProfitBricksIPBlockExists("profitbricks_ipblock.webserver_ip", &ipblock),
 					testAccCheckProfitBricksIPBlockAttributes("profitbricks_ipblock.webserver_ip", location),
 					resource.TestCheckResourceAttr("profitbricks_ipblock.webserver_ip", "location", location),
+					resource.TestCheckResourceAttr("profitbricks_ipblock.webserver_ip", "ip_consumers.#", "0"),
+					resource.TestCheckResourceAttrSet("profitbricks_ipblock.webserver_ip", "cidrs.0"),
 				),
 			},
 			{
@@ -40,6 +43,27 @@ func TestAccProfitBricksIPBlock_Basic(t *testing.T) {
 	})
 }
 
+func TestIPv4CIDRs(t *testing.T) {
+	cases := []struct {
+		ips   []string
+		cidrs []string
+	}{
+		{[]string{}, []string{}},
+		{[]string{"192.0.2.7"}, []string{"192.0.2.7/32"}},
+		{[]string{"192.0.2.3", "192.0.2.0", "192.0.2.2", "192.0.2.1"}, []string{"192.0.2.0/30"}},
+		{[]string{"192.0.2.1", "192.0.2.2", "192.0.2.3", "192.0.2.4"}, []string{"192.0.2.1/32", "192.0.2.2/31", "192.0.2.4/32"}},
+		{[]string{"192.0.2.255", "192.0.3.0"}, []string{"192.0.2.255/32", "192.0.3.0/32"}},
+		{[]string{"192.0.2.8", "198.51.100.9", "192.0.2.9", "192.0.2.8"}, []string{"192.0.2.8/31", "198.51.100.9/32"}},
+		{[]string{"255.255.255.254", "255.255.255.255"}, []string{"255.255.255.254/31"}},
+	}
+
+	for _, c := range cases {
+		if cidrs := ipv4CIDRs(c.ips); !reflect.DeepEqual(cidrs, c.cidrs) {
+			t.Errorf("ipv4CIDRs(%v) = %v, expected %v", c.ips, cidrs, c.cidrs)
+		}
+	}
+}
+
 func testAccCheckDProfitBricksIPBlockDestroyCheck(s *terraform.State) error {
 	client := testAccProvider.Meta().(*profitbricks.Client)
 	for _, rs := range s.RootModule().Resources {
@@ -2,22 +2,34 @@ package profitbricks
 
 import (
 	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
 )
 
+// lanFailoverLocks serializes the changes of the ip failover list of each LAN, the API only replaces the
+// whole list, so ipfailover resources on the same LAN would otherwise drop each other's entries
+var lanFailoverLocks sync.Map
+
 func resourceProfitBricksLanIPFailover() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceProfitBricksLanIPFailoverCreate,
 		Read:   resourceProfitBricksLanIPFailoverRead,
 		Update: resourceProfitBricksLanIPFailoverUpdate,
 		Delete: resourceProfitBricksLanIPFailoverDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceProfitBricksLanIPFailoverImport,
+		},
 		Schema: map[string]*schema.Schema{
 			"ip": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateIPv4Address,
 			},
 			"nicuuid": {
 				Type:     schema.TypeString,
@@ -26,6 +38,7 @@ func resourceProfitBricksLanIPFailover() *schema.Resource {
 			"lan_id": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"datacenter_id": {
 				Type:     schema.TypeString,
@@ -38,36 +51,23 @@ func resourceProfitBricksLanIPFailover() *schema.Resource {
 }
 
 func resourceProfitBricksLanIPFailoverCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*profitbricks.Client)
-	dcid := d.Get("datacenter_id").(string)
 	lanid := d.Get("lan_id").(string)
 	if lanid == "" {
 		return fmt.Errorf("'lan_id' is missing, please provide a valid lan ID ")
 	}
-	ip := d.Get("ip").(string)
-	nicUuid := d.Get("nicuuid").(string)
-	properties := &profitbricks.LanProperties{}
-
-	properties.IPFailover = &[]profitbricks.IPFailover{
-		{
-			IP:      ip,
-			NicUUID: nicUuid,
-		}}
-
-	if properties != nil {
-		lan, err := client.UpdateLan(dcid, lanid, *properties)
-		if err != nil {
-			return fmt.Errorf("An error occured while patching a lans failover group  %s %s", lanid, err)
-		}
-
-		// Wait, catching any errors
-		_, errState := getStateChangeConf(meta, d, lan.Headers.Get("Location"), schema.TimeoutCreate).WaitForState()
-		if errState != nil {
-			return errState
-		}
+	entry := profitbricks.IPFailover{
+		IP:      d.Get("ip").(string),
+		NicUUID: d.Get("nicuuid").(string),
+	}
 
-		d.SetId(lan.ID)
+	err := updateLanIPFailover(meta, d, d.Get("datacenter_id").(string), lanid, schema.TimeoutCreate, func(groups []profitbricks.IPFailover) []profitbricks.IPFailover {
+		return append(withoutIPFailover(groups, entry), entry)
+	})
+	if err != nil {
+		return fmt.Errorf("An error occured while patching a lans failover group  %s %w", lanid, err)
 	}
+
+	d.SetId(lanid)
 	return resourceProfitBricksLanIPFailoverRead(d, meta)
 }
 
@@ -82,76 +82,206 @@ func resourceProfitBricksLanIPFailoverRead(d *schema.ResourceData, meta interfac
 				return nil
 			}
 		}
-		return fmt.Errorf("An error occured while fetching a lan ID %s %s", d.Id(), err)
+		return fmt.Errorf("An error occured while fetching a lan ID %s %w", d.Id(), err)
 	}
 
-	d.Set("public", lan.Properties.Public)
-	d.Set("name", lan.Properties.Name)
-	d.Set("ip_failover", lan.Properties.IPFailover)
+	// the lan holds the entries of all ipfailover resources, only the one of this resource is of interest
+	ip := d.Get("ip").(string)
+	nicUuid := d.Get("nicuuid").(string)
+	found := false
+	if lan.Properties.IPFailover != nil {
+		for _, group := range *lan.Properties.IPFailover {
+			if group.IP == ip && group.NicUUID == nicUuid {
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		log.Printf("[INFO] IP failover of %s to nic %s not found in lan %s, removing it from the state", ip, nicUuid, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("lan_id", lan.ID)
 	d.Set("datacenter_id", d.Get("datacenter_id").(string))
 	return nil
 }
 
 func resourceProfitBricksLanIPFailoverUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*profitbricks.Client)
-	properties := &profitbricks.LanProperties{}
-	dcid := d.Get("datacenter_id").(string)
-	lanid := d.Get("lan_id").(string)
-	ip := d.Get("ip").(string)
-	nicUuid := d.Get("nicuuid").(string)
+	oldIp, newIp := d.GetChange("ip")
+	oldNicUuid, newNicUuid := d.GetChange("nicuuid")
+	previous := profitbricks.IPFailover{IP: oldIp.(string), NicUUID: oldNicUuid.(string)}
+	entry := profitbricks.IPFailover{IP: newIp.(string), NicUUID: newNicUuid.(string)}
 
-	properties.IPFailover = &[]profitbricks.IPFailover{
-		{
-			IP:      ip,
-			NicUUID: nicUuid,
-		}}
+	err := updateLanIPFailover(meta, d, d.Get("datacenter_id").(string), d.Get("lan_id").(string), schema.TimeoutUpdate, func(groups []profitbricks.IPFailover) []profitbricks.IPFailover {
+		return append(withoutIPFailover(withoutIPFailover(groups, previous), entry), entry)
+	})
+	if err != nil {
+		return fmt.Errorf("An error occured while patching a lan ID %s %w", d.Id(), err)
+	}
 
-	if properties != nil {
-		lan, err := client.UpdateLan(dcid, lanid, *properties)
-		if err != nil {
-			return fmt.Errorf("An error occured while patching a lan ID %s %s", d.Id(), err)
-		}
+	return resourceProfitBricksLanIPFailoverRead(d, meta)
+}
 
-		// Wait, catching any errors
-		_, errState := getStateChangeConf(meta, d, lan.Headers.Get("Location"), schema.TimeoutUpdate).WaitForState()
-		if errState != nil {
-			return errState
+func resourceProfitBricksLanIPFailoverDelete(d *schema.ResourceData, meta interface{}) error {
+	entry := profitbricks.IPFailover{
+		IP:      d.Get("ip").(string),
+		NicUUID: d.Get("nicuuid").(string),
+	}
+
+	err := updateLanIPFailover(meta, d, d.Get("datacenter_id").(string), d.Get("lan_id").(string), schema.TimeoutDelete, func(groups []profitbricks.IPFailover) []profitbricks.IPFailover {
+		return withoutIPFailover(groups, entry)
+	})
+	if err != nil {
+		if apiError, ok := err.(profitbricks.ApiError); !ok || apiError.HttpStatusCode() != 404 {
+			return fmt.Errorf("An error occured while removing a lans ipfailover groups dcId %s ID %s %w", d.Get("datacenter_id").(string), d.Id(), err)
 		}
 	}
-	return resourceProfitBricksLanIPFailoverRead(d, meta)
+
+	d.SetId("")
+	return nil
 }
 
-func resourceProfitBricksLanIPFailoverDelete(d *schema.ResourceData, meta interface{}) error {
+// resourceProfitBricksLanIPFailoverImport imports the ip failover of a lan by {datacenter}/{lan} when the lan has
+// a single one, or selects one of several by {datacenter}/{lan}/{ip} or {datacenter}/{lan}/{ip}/{nic}
+func resourceProfitBricksLanIPFailoverImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) < 2 || len(parts) > 4 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("Invalid import id %q. Expecting {datacenter}/{lan}, optionally followed by /{ip} or /{ip}/{nic}", d.Id())
+	}
+
 	client := meta.(*profitbricks.Client)
-	dcid := d.Get("datacenter_id").(string)
-	lanid := d.Get("lan_id").(string)
+	lan, err := client.GetLan(parts[0], parts[1])
+	if err != nil {
+		if apiError, ok := err.(profitbricks.ApiError); ok {
+			if apiError.HttpStatusCode() == 404 {
+				d.SetId("")
+				return nil, fmt.Errorf("Unable to find LAN %q in datacenter %q", parts[1], parts[0])
+			}
+		}
+		return nil, fmt.Errorf("Unable to retreive LAN %q: %w", parts[1], err)
+	}
 
-	//remove the failover group
-	properties := &profitbricks.LanProperties{
-		IPFailover: &[]profitbricks.IPFailover{},
+	matches := []profitbricks.IPFailover{}
+	all := []string{}
+	if lan.Properties.IPFailover != nil {
+		for _, group := range *lan.Properties.IPFailover {
+			all = append(all, fmt.Sprintf("%s/%s", group.IP, group.NicUUID))
+			if len(parts) > 2 && group.IP != parts[2] {
+				continue
+			}
+			if len(parts) > 3 && group.NicUUID != parts[3] {
+				continue
+			}
+			matches = append(matches, group)
+		}
 	}
 
-	ipfailover, err := client.UpdateLan(dcid, lanid, *properties)
-	if err != nil {
-		//try again in 90 seconds
-		time.Sleep(90 * time.Second)
-		ipfailover, err = client.UpdateLan(dcid, lanid, *properties)
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("Unable to find an ip failover matching %q in LAN %q", d.Id(), parts[1])
+	}
+	if len(matches) > 1 {
+		return nil, fmt.Errorf("LAN %q has %d ip failovers matching %q, add the ip and nic to the import id: %s", parts[1], len(matches), d.Id(), strings.Join(all, ", "))
+	}
+
+	log.Printf("[INFO] IP failover found: %+v", matches[0])
+
+	d.Set("datacenter_id", parts[0])
+	d.Set("lan_id", lan.ID)
+	d.Set("ip", matches[0].IP)
+	d.Set("nicuuid", matches[0].NicUUID)
+	d.SetId(lan.ID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// ipFailoverAttempts is how often the ip failover list of a lan is written before giving up on
+// other writers, e.g. another terraform run, changing it at the same time
+const ipFailoverAttempts = 5
+
+// updateLanIPFailover replaces the ip failover list of a lan by what change makes of the current list and waits
+// for the request. Changes of the same lan are serialized, so entries of other resources are kept. As other
+// clients may write the list at the same time, the list is read again afterwards, and the change is redone on
+// the most current list while it got lost or the API reports a conflict
+func updateLanIPFailover(meta interface{}, d *schema.ResourceData, dcId string, lanId string, timeoutType string, change func([]profitbricks.IPFailover) []profitbricks.IPFailover) error {
+	client := meta.(*profitbricks.Client)
+
+	lock, _ := lanFailoverLocks.LoadOrStore(dcId+"/"+lanId, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	wait := pollInterval(client, 10*time.Second)
+	for attempt := 1; ; attempt++ {
+		groups, err := getLanIPFailover(client, dcId, lanId)
+		if err != nil {
+			return err
+		}
+
+		if attempt > 1 && sameIPFailovers(change(groups), groups) {
+			return nil
+		}
+		if attempt > ipFailoverAttempts {
+			return fmt.Errorf("The ip failover list of lan %s kept being changed by others, gave up after %d attempts", lanId, ipFailoverAttempts)
+		}
+		if attempt > 1 {
+			log.Printf("[WARN] The ip failover list of lan %s was changed at the same time, retrying in %s (%d/%d)", lanId, wait, attempt, ipFailoverAttempts)
+			time.Sleep(wait)
+			// the lan may have been changed while sleeping
+			if groups, err = getLanIPFailover(client, dcId, lanId); err != nil {
+				return err
+			}
+		}
 
+		groups = change(groups)
+		lan, err := client.UpdateLan(dcId, lanId, profitbricks.LanProperties{IPFailover: &groups})
 		if err != nil {
-			if apiError, ok := err.(profitbricks.ApiError); ok {
-				if apiError.HttpStatusCode() != 404 {
-					return fmt.Errorf("An error occured while removing a lans ipfailover groups dcId %s ID %s %s", d.Get("datacenter_id").(string), d.Id(), err)
-				}
+			if profitbricks.IsHttpStatus(err, http.StatusConflict) {
+				continue
 			}
+			return err
 		}
+
+		// Wait, catching any errors
+		if _, errState := waitForRequest(meta, d, lan.Headers.Get("Location"), timeoutType); errState != nil {
+			return errState
+		}
+	}
+}
+
+// getLanIPFailover returns the ip failover list of a lan
+func getLanIPFailover(client *profitbricks.Client, dcId string, lanId string) ([]profitbricks.IPFailover, error) {
+	lan, err := client.GetLan(dcId, lanId)
+	if err != nil {
+		return nil, err
 	}
 
-	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, ipfailover.Headers.Get("Location"), schema.TimeoutDelete).WaitForState()
-	if errState != nil {
-		return errState
+	if lan.Properties.IPFailover == nil {
+		return []profitbricks.IPFailover{}, nil
 	}
+	return *lan.Properties.IPFailover, nil
+}
 
-	d.SetId("")
-	return nil
+// sameIPFailovers reports whether a and b have the same entries, in any order
+func sameIPFailovers(a []profitbricks.IPFailover, b []profitbricks.IPFailover) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, entry := range a {
+		if len(withoutIPFailover(b, entry)) == len(b) {
+			return false
+		}
+	}
+	return true
+}
+
+// withoutIPFailover returns groups without entry
+func withoutIPFailover(groups []profitbricks.IPFailover, entry profitbricks.IPFailover) []profitbricks.IPFailover {
+	result := []profitbricks.IPFailover{}
+	for _, group := range groups {
+		if group.IP != entry.IP || group.NicUUID != entry.NicUUID {
+			result = append(result, group)
+		}
+	}
+	return result
 }
@@ -1,10 +1,15 @@
 package profitbricks
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
 )
@@ -46,6 +51,107 @@ func TestAccProfitBricksLanIPFailover_Basic(t *testing.T) {
 	})
 }
 
+func TestAccProfitBricksLanIPFailover_Multiple(t *testing.T) {
+	var lan profitbricks.Lan
+	var ipfailover profitbricks.IPFailover
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksLanIPFailoverDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckProfitbricksLanIPFailoverConfig_multiple,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLanIPFailoverGroupExists("profitbricks_ipfailover.first", &lan, &ipfailover),
+					testAccCheckLanIPFailoverGroupExists("profitbricks_ipfailover.second", &lan, &ipfailover),
+				),
+			},
+			{
+				ResourceName: "profitbricks_ipfailover.second",
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["profitbricks_ipfailover.second"]
+					return fmt.Sprintf("%s/%s/%s", rs.Primary.Attributes["datacenter_id"], rs.Primary.Attributes["lan_id"], rs.Primary.Attributes["ip"]), nil
+				},
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestLanIPFailover_concurrentModification(t *testing.T) {
+	var mu sync.Mutex
+	other := profitbricks.IPFailover{IP: "10.0.0.2", NicUUID: "other-nic"}
+	groups := []profitbricks.IPFailover{other}
+	patches := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/requests/1/status":
+			w.Write([]byte(`{"metadata": {"status": "DONE"}}`))
+		case r.URL.Path == "/datacenters/dc/lans/1" && r.Method == http.MethodPatch:
+			patches++
+			if patches == 1 {
+				w.WriteHeader(http.StatusConflict)
+				w.Write([]byte(`{"httpStatus": 409, "messages": [{"errorCode": "200", "message": "Conflict"}]}`))
+				return
+			}
+			var properties profitbricks.LanProperties
+			if err := json.NewDecoder(r.Body).Decode(&properties); err != nil {
+				t.Errorf("err: %s", err)
+			}
+			groups = *properties.IPFailover
+			if patches == 2 {
+				// another client writes the list it read before this change
+				groups = []profitbricks.IPFailover{other}
+			}
+			w.Header().Set("Location", server.URL+"/requests/1/status")
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id": "1"}`))
+		case r.URL.Path == "/datacenters/dc/lans/1":
+			body, _ := json.Marshal(profitbricks.Lan{ID: "1", Properties: profitbricks.LanProperties{IPFailover: &groups}})
+			w.Write(body)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	config := Config{Token: "token", Endpoint: server.URL, PollInterval: 1}
+	client, err := config.Client("0.12")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceProfitBricksLanIPFailover().Schema, map[string]interface{}{
+		"datacenter_id": "dc",
+		"lan_id":        "1",
+		"ip":            "10.0.0.1",
+		"nicuuid":       "nic",
+	})
+	entry := profitbricks.IPFailover{IP: "10.0.0.1", NicUUID: "nic"}
+	err = updateLanIPFailover(client, d, "dc", "1", schema.TimeoutCreate, func(groups []profitbricks.IPFailover) []profitbricks.IPFailover {
+		return append(withoutIPFailover(groups, entry), entry)
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if patches != 3 {
+		t.Fatalf("expected the list to be written 3 times, got %d", patches)
+	}
+	if !sameIPFailovers(groups, []profitbricks.IPFailover{entry, other}) {
+		t.Fatalf("expected both entries to be kept, got %+v", groups)
+	}
+}
+
 func testAccCheckLanIPFailoverGroupExists(n string, lan *profitbricks.Lan, failover *profitbricks.IPFailover) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		client := testAccProvider.Meta().(*profitbricks.Client)
@@ -198,3 +304,58 @@ resource "profitbricks_server" "webserver" {
      ip ="${profitbricks_ipblock.webserver_ip.ips[0]}"
   }
 }`
+
+const testAccCheckProfitbricksLanIPFailoverConfig_multiple = `
+resource "profitbricks_datacenter" "foobar" {
+	name       = "ipfailover-test"
+	location = "us/las"
+}
+
+resource "profitbricks_ipblock" "webserver_ip" {
+  location = "us/las"
+  size = 2
+  name = "failover test"
+}
+
+resource "profitbricks_lan" "webserver_lan1" {
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  public = true
+  name = "terraform test"
+}
+
+resource "profitbricks_server" "webserver" {
+  count = 2
+  name = "server-${count.index}"
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  cores = 1
+  ram = 1024
+  availability_zone = "ZONE_1"
+  cpu_family = "AMD_OPTERON"
+	image_name = "centos:latest"
+	image_password = "K3tTj8G14a3EgKyNeeiY"
+  volume {
+    name = "system"
+    size = 5
+    disk_type = "SSD"
+  }
+  nic {
+    lan = "${profitbricks_lan.webserver_lan1.id}"
+    dhcp = true
+    firewall_active = true
+    ip = "${profitbricks_ipblock.webserver_ip.ips[count.index]}"
+  }
+}
+
+resource "profitbricks_ipfailover" "first" {
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  lan_id = "${profitbricks_lan.webserver_lan1.id}"
+  ip = "${profitbricks_ipblock.webserver_ip.ips[0]}"
+  nicuuid = "${profitbricks_server.webserver[0].primary_nic}"
+}
+
+resource "profitbricks_ipfailover" "second" {
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  lan_id = "${profitbricks_lan.webserver_lan1.id}"
+  ip = "${profitbricks_ipblock.webserver_ip.ips[1]}"
+  nicuuid = "${profitbricks_server.webserver[1].primary_nic}"
+}`
@@ -3,8 +3,12 @@ package profitbricks
 import (
 	"fmt"
 	"log"
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
 )
@@ -18,6 +22,7 @@ func resourcek8sCluster() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceProfitBricksK8sClusterImport,
 		},
+		CustomizeDiff: resourcek8sClusterCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -28,27 +33,43 @@ func resourcek8sCluster() *schema.Resource {
 				Type:        schema.TypeString,
 				Description: "The desired kubernetes version",
 				Optional:    true,
+				Computed:    true,
 			},
-			"maintenance_window": {
+			"maintenance_window": maintenanceWindowSchema(),
+			"public": {
+				Type:        schema.TypeBool,
+				Description: "Whether the cluster is public, the nodes of a private cluster have no public IPs",
+				Optional:    true,
+				Default:     true,
+				ForceNew:    true,
+			},
+			"api_subnet_allow_list": {
 				Type:        schema.TypeList,
-				Description: "A maintenance window comprise of a day of the week and a time for maintenance to be allowed",
+				Description: "The networks, in CIDR notation, allowed to access the kubernetes API of the cluster. Any network may when empty",
 				Optional:    true,
-				MaxItems:    1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"time": {
-							Type:        schema.TypeString,
-							Description: "A clock time in the day when maintenance is allowed",
-							Required:    true,
-						},
-						"day_of_the_week": {
-							Type:        schema.TypeString,
-							Description: "Day of the week when maintenance is allowed",
-							Required:    true,
-						},
-					},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateCIDR,
 				},
 			},
+			"available_upgrade_versions": {
+				Type:        schema.TypeList,
+				Description: "The kubernetes versions the cluster can be upgraded to",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"viable_node_pool_versions": {
+				Type:        schema.TypeList,
+				Description: "The kubernetes versions node pools of the cluster can run",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"kube_config": {
+				Type:        schema.TypeString,
+				Description: "The kubeconfig file contents for the cluster",
+				Computed:    true,
+				Sensitive:   true,
+			},
 		},
 		Timeouts: &resourceDefaultTimeouts,
 	}
@@ -57,9 +78,15 @@ func resourcek8sCluster() *schema.Resource {
 func resourcek8sClusterCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*profitbricks.Client)
 
-	cluster := profitbricks.KubernetesCluster{
-		Properties: &profitbricks.KubernetesClusterProperties{
-			Name: d.Get("name").(string),
+	public := d.Get("public").(bool)
+	apiSubnetAllowList := expandAPISubnetAllowList(d)
+	cluster := k8sClusterWithExtras{
+		Properties: k8sClusterPropertiesWithExtras{
+			KubernetesClusterProperties: profitbricks.KubernetesClusterProperties{
+				Name: d.Get("name").(string),
+			},
+			Public:             &public,
+			APISubnetAllowList: &apiSubnetAllowList,
 		},
 	}
 
@@ -68,44 +95,23 @@ func resourcek8sClusterCreate(d *schema.ResourceData, meta interface{}) error {
 		cluster.Properties.K8sVersion = k8svVal.(string)
 	}
 
-	if _, mwOk := d.GetOk("maintenance_window.0"); mwOk {
-		cluster.Properties.MaintenanceWindow = &profitbricks.MaintenanceWindow{}
-	}
-
-	if mtVal, mtOk := d.GetOk("maintenance_window.0.time"); mtOk {
-		log.Printf("[INFO] Setting Maintenance Window Time to : %s", mtVal.(string))
-		cluster.Properties.MaintenanceWindow.Time = mtVal.(string)
-	}
-
-	if mdVal, mdOk := d.GetOk("maintenance_window.0.day_of_the_week"); mdOk {
-		cluster.Properties.MaintenanceWindow.DayOfTheWeek = mdVal.(string)
-	}
+	cluster.Properties.MaintenanceWindow = expandMaintenanceWindow(d)
 
-	createdCluster, err := client.CreateKubernetesCluster(cluster)
+	createdCluster, err := createK8sClusterWithExtras(client, cluster)
 
 	if err != nil {
 		d.SetId("")
-		return fmt.Errorf("Error creating k8s cluster: %s", err)
+		return fmt.Errorf("Error creating k8s cluster: %w", err)
 	}
 
 	d.SetId(createdCluster.ID)
 	log.Printf("[INFO] Created k8s cluster: %s", d.Id())
 
-	for {
-		log.Printf("[INFO] Waiting for cluster %s to be ready...", d.Id())
-		time.Sleep(5 * time.Second)
-
-		clusterReady, rsErr := k8sClusterReady(client, d)
-
-		if rsErr != nil {
-			return fmt.Errorf("Error while checking readiness status of k8s cluster %s: %s", d.Id(), rsErr)
-		}
-
-		if clusterReady && rsErr == nil {
-			log.Printf("[INFO] k8s cluster ready: %s", d.Id())
-			break
-		}
+	log.Printf("[INFO] Waiting for cluster %s to be ready...", d.Id())
+	if _, err := k8sClusterStateChangeConf(client, d, schema.TimeoutCreate).WaitForState(); err != nil {
+		return fmt.Errorf("Error while waiting for k8s cluster %s to be ready: %w", d.Id(), err)
 	}
+	log.Printf("[INFO] k8s cluster ready: %s", d.Id())
 
 	return resourcek8sClusterRead(d, meta)
 }
@@ -113,7 +119,7 @@ func resourcek8sClusterCreate(d *schema.ResourceData, meta interface{}) error {
 func resourcek8sClusterRead(d *schema.ResourceData, meta interface{}) error {
 
 	client := meta.(*profitbricks.Client)
-	cluster, err := client.GetKubernetesCluster(d.Id())
+	cluster, err := getK8sClusterWithExtras(client, d.Id())
 
 	if err != nil {
 		if apiError, ok := err.(profitbricks.ApiError); ok {
@@ -122,20 +128,75 @@ func resourcek8sClusterRead(d *schema.ResourceData, meta interface{}) error {
 				return nil
 			}
 		}
-		return fmt.Errorf("Error while fetching k8s cluster %s: %s", d.Id(), err)
+		return fmt.Errorf("Error while fetching k8s cluster %s: %w", d.Id(), err)
 	}
 
 	log.Printf("[INFO] Successfully retreived cluster %s: %+v", d.Id(), cluster)
 
+	if err := d.Set("name", cluster.Properties.Name); err != nil {
+		return err
+	}
+
+	if err := d.Set("k8s_version", cluster.Properties.K8sVersion); err != nil {
+		return err
+	}
+
+	if cluster.Properties.MaintenanceWindow != nil {
+		if err := d.Set("maintenance_window", flattenMaintenanceWindow(cluster.Properties.MaintenanceWindow)); err != nil {
+			return err
+		}
+	}
+
+	// clusters created before the API knew about private clusters are public
+	public := true
+	if cluster.Properties.Public != nil {
+		public = *cluster.Properties.Public
+	}
+	if err := d.Set("public", public); err != nil {
+		return err
+	}
+
+	apiSubnetAllowList := []string{}
+	if cluster.Properties.APISubnetAllowList != nil {
+		apiSubnetAllowList = *cluster.Properties.APISubnetAllowList
+	}
+	if err := d.Set("api_subnet_allow_list", apiSubnetAllowList); err != nil {
+		return err
+	}
+
+	if err := d.Set("available_upgrade_versions", cluster.Properties.AvailableUpgradeVersions); err != nil {
+		return err
+	}
+	if err := d.Set("viable_node_pool_versions", cluster.Properties.ViableNodePoolVersions); err != nil {
+		return err
+	}
+
+	if cluster.Metadata != nil && cluster.Metadata.State == "ACTIVE" {
+		kubeConfig, err := client.GetKubeconfig(d.Id())
+		if err != nil {
+			return fmt.Errorf("Error while fetching kubeconfig for k8s cluster %s: %w", d.Id(), err)
+		}
+		if err := d.Set("kube_config", kubeConfig); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func resourcek8sClusterUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*profitbricks.Client)
-	request := profitbricks.UpdatedKubernetesCluster{}
-
-	request.Properties = &profitbricks.KubernetesClusterProperties{
-		Name: d.Get("name").(string),
+	request := k8sClusterWithExtras{}
+
+	// the cluster properties are replaced by the request, so all of them are sent, changed or not
+	public := d.Get("public").(bool)
+	request.Properties = k8sClusterPropertiesWithExtras{
+		KubernetesClusterProperties: profitbricks.KubernetesClusterProperties{
+			Name:              d.Get("name").(string),
+			K8sVersion:        d.Get("k8s_version").(string),
+			MaintenanceWindow: expandMaintenanceWindow(d),
+		},
+		Public: &public,
 	}
 
 	if d.HasChange("name") {
@@ -146,52 +207,36 @@ func resourcek8sClusterUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	log.Printf("[INFO] Attempting update cluster Id %s", d.Id())
 
+	upgradeVersion := ""
 	if d.HasChange("k8s_version") {
 		oldk8sVersion, newk8sVersion := d.GetChange("k8s_version")
 		log.Printf("[INFO] k8s version changed from %+v to %+v", oldk8sVersion, newk8sVersion)
-		if newk8sVersion != nil {
+		if newk8sVersion.(string) != "" {
 			request.Properties.K8sVersion = newk8sVersion.(string)
-		}
-	}
-
-	if d.HasChange("maintenance_window.0") {
-
-		_, newMw := d.GetChange("maintenance_window.0")
-
-		if newMw.(map[string]interface{}) != nil {
 
-			updateMaintenanceWindow := false
-			maintenanceWindow := &profitbricks.MaintenanceWindow{
-				DayOfTheWeek: d.Get("maintenance_window.0.day_of_the_week").(string),
-				Time:         d.Get("maintenance_window.0.time").(string),
+			cluster, err := getK8sClusterWithExtras(client, d.Id())
+			if err != nil {
+				return fmt.Errorf("Error while fetching k8s cluster %s: %w", d.Id(), err)
 			}
-
-			if d.HasChange("maintenance_window.0.day_of_the_week") {
-				oldMd, newMd := d.GetChange("maintenance_window.0.day_of_the_week")
-				if newMd.(string) != "" {
-					log.Printf("[INFO] k8s maintenance window DOW changed from %+v to %+v", oldMd, newMd)
-					updateMaintenanceWindow = true
-					maintenanceWindow.DayOfTheWeek = newMd.(string)
-				}
+			if cluster.Properties.K8sVersion != newk8sVersion.(string) {
+				upgradeVersion = newk8sVersion.(string)
 			}
+		}
+	}
 
-			if d.HasChange("maintenance_window.0.time") {
-
-				oldMt, newMt := d.GetChange("maintenance_window.0.time")
-				if newMt.(string) != "" {
-					log.Printf("[INFO] k8s maintenance window time changed from %+v to %+v", oldMt, newMt)
-					updateMaintenanceWindow = true
-					maintenanceWindow.Time = newMt.(string)
-				}
-			}
+	if d.HasChange("maintenance_window") && request.Properties.MaintenanceWindow != nil {
+		// the API always keeps a window, removing the block keeps the current one
+		log.Printf("[INFO] k8s cluster maintenance window changed to %+v", *request.Properties.MaintenanceWindow)
+	}
 
-			if updateMaintenanceWindow == true {
-				request.Properties.MaintenanceWindow = maintenanceWindow
-			}
-		}
+	// an empty allow list allows any network
+	apiSubnetAllowList := expandAPISubnetAllowList(d)
+	if d.HasChange("api_subnet_allow_list") {
+		log.Printf("[INFO] k8s cluster api subnet allow list changed to %+v", apiSubnetAllowList)
 	}
+	request.Properties.APISubnetAllowList = &apiSubnetAllowList
 
-	_, err := client.UpdateKubernetesCluster(d.Id(), request)
+	_, err := updateK8sClusterWithExtras(client, d.Id(), request)
 
 	if err != nil {
 		if apiError, ok := err.(profitbricks.ApiError); ok {
@@ -199,28 +244,79 @@ func resourcek8sClusterUpdate(d *schema.ResourceData, meta interface{}) error {
 				d.SetId("")
 				return nil
 			}
-			return fmt.Errorf("Error while updating k8s cluster: %s", err)
+			return fmt.Errorf("Error while updating k8s cluster: %w", err)
 		}
-		return fmt.Errorf("Error while updating k8s cluster %s: %s", d.Id(), err)
+		return fmt.Errorf("Error while updating k8s cluster %s: %w", d.Id(), err)
 	}
 
-	for {
-		log.Printf("[INFO] Waiting for cluster %s to be ready...", d.Id())
-		time.Sleep(5 * time.Second)
+	log.Printf("[INFO] Waiting for cluster %s to be ready...", d.Id())
+	stateConf := k8sClusterStateChangeConf(client, d, schema.TimeoutUpdate)
+	if upgradeVersion != "" {
+		// the cluster may still report ACTIVE right after the request, the upgrade is done once it runs the new version
+		stateConf.Refresh = k8sClusterUpgradeRefreshFunc(client, d.Id(), upgradeVersion)
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error while waiting for k8s cluster %s to be ready: %w", d.Id(), err)
+	}
+	log.Printf("[INFO] k8s cluster ready: %s", d.Id())
 
-		clusterReady, rsErr := k8sClusterReady(client, d)
+	return resourcek8sClusterRead(d, meta)
+}
 
-		if rsErr != nil {
-			return fmt.Errorf("Error while checking readiness status of k8s cluster %s: %s", d.Id(), rsErr)
-		}
+// resourcek8sClusterCustomizeDiff rejects downgrades of the kubernetes version of a cluster and upgrades to
+// versions the API does not offer for it, before the cluster is touched
+func resourcek8sClusterCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("k8s_version") || !diff.NewValueKnown("k8s_version") {
+		return nil
+	}
+
+	oldVersion, newVersion := diff.GetChange("k8s_version")
+	if oldVersion.(string) == "" || newVersion.(string) == "" {
+		return nil
+	}
+	if compareK8sVersions(newVersion.(string), oldVersion.(string)) < 0 {
+		return fmt.Errorf("The kubernetes version of k8s cluster %s cannot be downgraded from %s to %s", diff.Id(), oldVersion.(string), newVersion.(string))
+	}
 
-		if clusterReady && rsErr == nil {
-			log.Printf("[INFO] k8s cluster ready: %s", d.Id())
-			break
+	client := meta.(*profitbricks.Client)
+	cluster, err := getK8sClusterWithExtras(client, diff.Id())
+	if err != nil {
+		return fmt.Errorf("Error while fetching k8s cluster %s: %w", diff.Id(), err)
+	}
+	// the version may already have been upgraded outside of terraform
+	if cluster.Properties.K8sVersion == newVersion.(string) {
+		return nil
+	}
+	for _, version := range cluster.Properties.AvailableUpgradeVersions {
+		if version == newVersion.(string) {
+			return nil
 		}
 	}
 
-	return resourcek8sClusterRead(d, meta)
+	return fmt.Errorf("k8s cluster %s cannot be upgraded from %s to %s, it is not one of the available upgrade versions: %s", diff.Id(), oldVersion.(string), newVersion.(string), strings.Join(cluster.Properties.AvailableUpgradeVersions, ", "))
+}
+
+// compareK8sVersions compares two dotted kubernetes versions like 1.18.5 by their numbers, returning
+// -1, 0 or 1 when a is lower, equal or higher than b. Parts which are not numbers count as 0
+func compareK8sVersions(a string, b string) int {
+	aParts := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bParts := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aPart, bPart int
+		if i < len(aParts) {
+			aPart, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			bPart, _ = strconv.Atoi(bParts[i])
+		}
+		if aPart != bPart {
+			if aPart < bPart {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
 }
 
 func resourcek8sClusterDelete(d *schema.ResourceData, meta interface{}) error {
@@ -234,50 +330,140 @@ func resourcek8sClusterDelete(d *schema.ResourceData, meta interface{}) error {
 				d.SetId("")
 				return nil
 			}
-			return fmt.Errorf("Error while deleting k8s cluster: %s", err)
+			return fmt.Errorf("Error while deleting k8s cluster: %w", err)
 		}
 
-		return fmt.Errorf("Error while deleting k8s cluster %s: %s", d.Id(), err)
+		return fmt.Errorf("Error while deleting k8s cluster %s: %w", d.Id(), err)
 	}
 
-	for {
-		log.Printf("[INFO] Waiting for cluster %s to be deleted...", d.Id())
-		time.Sleep(5 * time.Second)
-
-		clusterdDeleted, dsErr := k8sClusterDeleted(client, d)
-
-		if dsErr != nil {
-			return fmt.Errorf("Error while checking deletion status of k8s cluster %s: %s", d.Id(), dsErr)
-		}
+	log.Printf("[INFO] Waiting for cluster %s to be deleted...", d.Id())
+	deleteConf := &resource.StateChangeConf{
+		Pending:    []string{"DESTROYING", "ACTIVE", "BUSY"},
+		Target:     []string{"DELETED"},
+		Refresh:    k8sClusterDeletedRefreshFunc(client, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		MinTimeout: pollInterval(client, 5*time.Second),
+		Delay:      pollInterval(client, 5*time.Second),
+	}
 
-		if clusterdDeleted && dsErr == nil {
-			log.Printf("[INFO] Successfully deleted k8s cluster: %s", d.Id())
-			break
-		}
+	if _, err := deleteConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error while waiting for k8s cluster %s to be deleted: %w", d.Id(), err)
 	}
+	log.Printf("[INFO] Successfully deleted k8s cluster: %s", d.Id())
 
 	return nil
 }
 
-func k8sClusterReady(client *profitbricks.Client, d *schema.ResourceData) (bool, error) {
-	subjectCluster, err := client.GetKubernetesCluster(d.Id())
+// k8sClusterStateChangeConf waits for a k8s cluster to reach the ACTIVE state
+func k8sClusterStateChangeConf(client *profitbricks.Client, d *schema.ResourceData, timeoutType string) *resource.StateChangeConf {
+	return &resource.StateChangeConf{
+		Pending:    []string{"DEPLOYING", "UPDATING", "BUSY"},
+		Target:     []string{"ACTIVE"},
+		Refresh:    k8sClusterStateRefreshFunc(client, d.Id()),
+		Timeout:    d.Timeout(timeoutType),
+		MinTimeout: pollInterval(client, 5*time.Second),
+		Delay:      pollInterval(client, 5*time.Second),
+	}
+}
 
-	if err != nil {
-		return true, fmt.Errorf("Error checking k8s cluster status: %s", err)
+// k8sClusterStateRefreshFunc reports the metadata state of a k8s cluster
+func k8sClusterStateRefreshFunc(client *profitbricks.Client, clusterID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		cluster, err := client.GetKubernetesCluster(clusterID)
+		if err != nil {
+			return nil, "", fmt.Errorf("Error checking k8s cluster status: %w", err)
+		}
+		if cluster.Metadata == nil {
+			return cluster, "BUSY", nil
+		}
+		if cluster.Metadata.State == "FAILED" {
+			return nil, "", fmt.Errorf("k8s cluster %s is in a FAILED state", clusterID)
+		}
+		return cluster, cluster.Metadata.State, nil
 	}
-	return subjectCluster.Metadata.State == "ACTIVE", nil
 }
 
-func k8sClusterDeleted(client *profitbricks.Client, d *schema.ResourceData) (bool, error) {
-	_, err := client.GetKubernetesCluster(d.Id())
+// k8sClusterUpgradeRefreshFunc reports the metadata state of a k8s cluster being upgraded to version, an ACTIVE
+// cluster still running another version is reported as UPDATING
+func k8sClusterUpgradeRefreshFunc(client *profitbricks.Client, clusterID string, version string) resource.StateRefreshFunc {
+	refresh := k8sClusterStateRefreshFunc(client, clusterID)
+	return func() (interface{}, string, error) {
+		result, state, err := refresh()
+		if err != nil || state != "ACTIVE" {
+			return result, state, err
+		}
+		if cluster := result.(*profitbricks.KubernetesCluster); cluster.Properties != nil && cluster.Properties.K8sVersion != version {
+			log.Printf("[INFO] k8s cluster %s runs version %s, waiting for %s", clusterID, cluster.Properties.K8sVersion, version)
+			return cluster, "UPDATING", nil
+		}
+		return result, state, nil
+	}
+}
 
-	if err != nil {
-		if apiError, ok := err.(profitbricks.ApiError); ok {
-			if apiError.HttpStatusCode() == 404 {
-				return true, nil
+// k8sClusterDeletedRefreshFunc reports DELETED once a k8s cluster can no longer be found
+func k8sClusterDeletedRefreshFunc(client *profitbricks.Client, clusterID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		cluster, err := client.GetKubernetesCluster(clusterID)
+		if err != nil {
+			if apiError, ok := err.(profitbricks.ApiError); ok && apiError.HttpStatusCode() == 404 {
+				return clusterID, "DELETED", nil
 			}
-			return true, fmt.Errorf("Error checking k8s cluster deletion status: %s", err)
+			return nil, "", fmt.Errorf("Error checking k8s cluster deletion status: %w", err)
 		}
+		if cluster.Metadata == nil {
+			return cluster, "BUSY", nil
+		}
+		return cluster, cluster.Metadata.State, nil
 	}
-	return false, nil
+}
+
+type k8sClusterPropertiesWithExtras struct {
+	profitbricks.KubernetesClusterProperties
+	Public                   *bool     `json:"public,omitempty"`
+	APISubnetAllowList       *[]string `json:"apiSubnetAllowList,omitempty"`
+	AvailableUpgradeVersions []string  `json:"availableUpgradeVersions,omitempty"`
+	ViableNodePoolVersions   []string  `json:"viableNodePoolVersions,omitempty"`
+}
+
+type k8sClusterWithExtras struct {
+	ID         string                         `json:"id,omitempty"`
+	Metadata   *profitbricks.Metadata         `json:"metadata,omitempty"`
+	Properties k8sClusterPropertiesWithExtras `json:"properties"`
+}
+
+type k8sClustersWithExtras struct {
+	Items []k8sClusterWithExtras `json:"items,omitempty"`
+}
+
+func getK8sClusterWithExtras(client *profitbricks.Client, clusterId string) (*k8sClusterWithExtras, error) {
+	ret := &k8sClusterWithExtras{}
+	err := client.Get(fmt.Sprintf("/k8s/%s", clusterId), ret, http.StatusOK)
+	return ret, err
+}
+
+func createK8sClusterWithExtras(client *profitbricks.Client, cluster k8sClusterWithExtras) (*k8sClusterWithExtras, error) {
+	ret := &k8sClusterWithExtras{}
+	err := client.PostAcc("/k8s", cluster, ret)
+	return ret, err
+}
+
+func updateK8sClusterWithExtras(client *profitbricks.Client, clusterId string, cluster k8sClusterWithExtras) (*k8sClusterWithExtras, error) {
+	ret := &k8sClusterWithExtras{}
+	err := client.PutAcc(fmt.Sprintf("/k8s/%s", clusterId), cluster, ret)
+	return ret, err
+}
+
+// expandAPISubnetAllowList returns the api_subnet_allow_list of a k8s cluster, an empty list when none is set
+func expandAPISubnetAllowList(d *schema.ResourceData) []string {
+	apiSubnetAllowList := []string{}
+	for _, network := range d.Get("api_subnet_allow_list").([]interface{}) {
+		apiSubnetAllowList = append(apiSubnetAllowList, network.(string))
+	}
+	return apiSubnetAllowList
+}
+
+func listK8sClustersWithExtras(client *profitbricks.Client) (*k8sClustersWithExtras, error) {
+	ret := &k8sClustersWithExtras{}
+	err := client.Get("/k8s", ret, http.StatusOK)
+	return ret, err
 }
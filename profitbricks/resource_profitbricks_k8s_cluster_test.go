@@ -2,6 +2,8 @@ package profitbricks
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
@@ -25,6 +27,9 @@ func TestAccProfitBricksk8sCluster_Basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckProfitBricksk8sClusterExists("profitbricks_k8s_cluster.example", &k8sCluster),
 					resource.TestCheckResourceAttr("profitbricks_k8s_cluster.example", "name", k8sClusterName),
+					resource.TestCheckResourceAttrSet("profitbricks_k8s_cluster.example", "kube_config"),
+					resource.TestCheckResourceAttr("profitbricks_k8s_cluster.example", "public", "true"),
+					resource.TestCheckResourceAttr("profitbricks_k8s_cluster.example", "api_subnet_allow_list.#", "0"),
 				),
 			},
 			{
@@ -32,6 +37,8 @@ func TestAccProfitBricksk8sCluster_Basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckProfitBricksk8sClusterExists("profitbricks_k8s_cluster.example", &k8sCluster),
 					resource.TestCheckResourceAttr("profitbricks_k8s_cluster.example", "name", "example-renamed"),
+					resource.TestCheckResourceAttr("profitbricks_k8s_cluster.example", "api_subnet_allow_list.#", "2"),
+					resource.TestCheckResourceAttr("profitbricks_k8s_cluster.example", "api_subnet_allow_list.0", "1.2.3.0/24"),
 				),
 			},
 		},
@@ -86,6 +93,104 @@ func testAccCheckProfitBricksk8sClusterExists(n string, k8sCluster *profitbricks
 	}
 }
 
+func TestAccProfitBricksk8sCluster_InvalidMaintenanceWindow(t *testing.T) {
+	config := fmt.Sprintf(testAccCheckProfitBricksk8sClusterConfigBasic, "example")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      strings.Replace(config, `"Sunday"`, `"sunday"`, 1),
+				ExpectError: regexp.MustCompile(`must be one of Monday, Tuesday`),
+			},
+			{
+				Config:      strings.Replace(config, `"09:00:00Z"`, `"9:00"`, 1),
+				ExpectError: regexp.MustCompile(`must be a time formatted as HH:MM:SS`),
+			},
+		},
+	})
+}
+
+func TestAccProfitBricksk8sCluster_InvalidAPISubnetAllowList(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      strings.Replace(testAccCheckProfitBricksk8sClusterConfigUpdate, `"1.2.3.0/24"`, `"1.2.3.0"`, 1),
+				ExpectError: regexp.MustCompile(`must be a network in CIDR notation`),
+			},
+		},
+	})
+}
+
+func TestAccProfitBricksk8sCluster_InvalidVersionChange(t *testing.T) {
+	config := fmt.Sprintf(testAccCheckProfitBricksk8sClusterConfigBasic, "example")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksk8sClusterDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				Config:      strings.Replace(config, `"1.18.5"`, `"1.17.9"`, 1),
+				ExpectError: regexp.MustCompile(`cannot be downgraded from 1.18.5 to 1.17.9`),
+			},
+			{
+				Config:      strings.Replace(config, `"1.18.5"`, `"1.99.0"`, 1),
+				ExpectError: regexp.MustCompile(`it is not one of the available upgrade versions`),
+			},
+		},
+	})
+}
+
+func TestCompareK8sVersions(t *testing.T) {
+	for _, tc := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.18.5", "1.18.5", 0},
+		{"1.18.5", "1.18.10", -1},
+		{"1.19.0", "1.18.10", 1},
+		{"1.18", "1.18.0", 0},
+		{"v1.18.6", "1.18.5", 1},
+	} {
+		if actual := compareK8sVersions(tc.a, tc.b); actual != tc.expected {
+			t.Errorf("expected %d comparing %s to %s, got %d", tc.expected, tc.a, tc.b, actual)
+		}
+	}
+}
+
+func TestValidateCIDR(t *testing.T) {
+	for value, valid := range map[string]bool{
+		"1.2.3.0/24":    true,
+		"10.0.0.1/32":   true,
+		"2001:db8::/32": true,
+		"1.2.3.4":       false,
+		"1.2.3.0/33":    false,
+		"not-a-network": false,
+		"":              false,
+	} {
+		_, errs := validateCIDR(value, "api_subnet_allow_list.0")
+		if valid && len(errs) > 0 {
+			t.Errorf("expected %q to be valid, got %v", value, errs)
+		}
+		if !valid && len(errs) == 0 {
+			t.Errorf("expected %q to be invalid", value)
+		}
+	}
+}
+
 const testAccCheckProfitBricksk8sClusterConfigBasic = `
 resource "profitbricks_k8s_cluster" "example" {
   name        = "%s"
@@ -104,4 +209,5 @@ resource "profitbricks_k8s_cluster" "example" {
     day_of_the_week = "Monday"
     time            = "10:30:00Z"
   }
+  api_subnet_allow_list = ["1.2.3.0/24", "10.0.0.0/16"]
 }`
@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
+	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
 )
@@ -19,6 +22,7 @@ func resourcek8sNodePool() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceProfitBricksK8sNodepoolImport,
 		},
+		CustomizeDiff: resourcek8sNodePoolCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -38,14 +42,16 @@ func resourcek8sNodePool() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"min_node_count": {
-							Type:        schema.TypeInt,
-							Description: "The minimum number of worker nodes the node pool can scale down to. Should be less than max_node_count",
-							Required:    true,
+							Type:         schema.TypeInt,
+							Description:  "The minimum number of worker nodes the node pool can scale down to. Should be less than max_node_count",
+							Required:     true,
+							ValidateFunc: validateNodeCount,
 						},
 						"max_node_count": {
-							Type:        schema.TypeInt,
-							Description: "The maximum number of worker nodes that the node pool can scale to. Should be greater than min_node_count",
-							Required:    true,
+							Type:         schema.TypeInt,
+							Description:  "The maximum number of worker nodes that the node pool can scale to. Should be greater than min_node_count",
+							Required:     true,
+							ValidateFunc: validateNodeCount,
 						},
 					},
 				},
@@ -58,26 +64,28 @@ func resourcek8sNodePool() *schema.Resource {
 					Type: schema.TypeInt,
 				},
 			},
-			"maintenance_window": {
-				Type:        schema.TypeList,
-				Description: "A maintenance window comprise of a day of the week and a time for maintenance to be allowed",
-				Optional:    true,
-				MaxItems:    1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"time": {
-							Type:        schema.TypeString,
-							Description: "A clock time in the day when maintenance is allowed",
-							Required:    true,
-						},
-						"day_of_the_week": {
-							Type:        schema.TypeString,
-							Description: "Day of the week when maintenance is allowed",
-							Required:    true,
-						},
-					},
-				},
+			"gateway_ip": {
+				Type:         schema.TypeString,
+				Description:  "The IP of the gateway the nodes of a private cluster reach the internet through",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateIPv4Address,
+			},
+			"labels": {
+				Type:         schema.TypeMap,
+				Description:  "The kubernetes labels of the nodes of the node pool",
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateK8sLabels,
+			},
+			"annotations": {
+				Type:         schema.TypeMap,
+				Description:  "The kubernetes annotations of the nodes of the node pool",
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateK8sAnnotations,
 			},
+			"maintenance_window": maintenanceWindowSchema(),
 			"datacenter_id": {
 				Type:        schema.TypeString,
 				Description: "The UUID of the VDC",
@@ -92,6 +100,7 @@ func resourcek8sNodePool() *schema.Resource {
 				Type:        schema.TypeString,
 				Description: "CPU Family",
 				Required:    true,
+				ForceNew:    true,
 			},
 			"availability_zone": {
 				Type:        schema.TypeString,
@@ -102,11 +111,15 @@ func resourcek8sNodePool() *schema.Resource {
 				Type:        schema.TypeString,
 				Description: "Storage type to use",
 				Required:    true,
+				ForceNew:    true,
 			},
 			"node_count": {
-				Type:        schema.TypeInt,
-				Description: "The number of nodes in this node pool",
-				Required:    true,
+				Type:             schema.TypeInt,
+				Description:      "The number of nodes in this node pool, the autoscaler changes it within the auto_scaling range",
+				Optional:         true,
+				Computed:         true,
+				ValidateFunc:     validateNodeCount,
+				DiffSuppressFunc: suppressNodeCountDiffWithAutoScaling,
 			},
 			"cores_count": {
 				Type:        schema.TypeInt,
@@ -123,6 +136,35 @@ func resourcek8sNodePool() *schema.Resource {
 				Description: "The total allocated storage capacity of a node in GB",
 				Required:    true,
 			},
+			"nodes": {
+				Type:        schema.TypeList,
+				Description: "The nodes of the node pool",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"public_ip": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"k8s_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 		Timeouts: &resourceDefaultTimeouts,
 	}
@@ -160,6 +202,15 @@ func resourcek8sNodePoolCreate(d *schema.ResourceData, meta interface{}) error {
 		k8sNodepool.Properties.AutoScaling.MaxNodeCount = uint32(asmxnVal.(int))
 	}
 
+	if k8sNodepool.Properties.NodeCount == 0 {
+		if k8sNodepool.Properties.AutoScaling == nil {
+			d.SetId("")
+			return fmt.Errorf("Error creating k8s node pool: node_count has to be set unless auto_scaling is")
+		}
+		// auto scaling starts with the smallest node pool
+		k8sNodepool.Properties.NodeCount = k8sNodepool.Properties.AutoScaling.MinNodeCount
+	}
+
 	if k8sNodepool.Properties.AutoScaling != nil && k8sNodepool.Properties.AutoScaling.MinNodeCount != 0 && k8sNodepool.Properties.AutoScaling.MaxNodeCount != 0 && k8sNodepool.Properties.AutoScaling.MinNodeCount != k8sNodepool.Properties.AutoScaling.MaxNodeCount {
 		log.Printf("[INFO] Autoscaling is on, doing some extra checks for k8s node pool")
 
@@ -174,18 +225,7 @@ func resourcek8sNodePoolCreate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	if _, mwOk := d.GetOk("maintenance_window.0"); mwOk {
-		k8sNodepool.Properties.MaintenanceWindow = &profitbricks.MaintenanceWindow{}
-	}
-
-	if mtVal, mtOk := d.GetOk("maintenance_window.0.time"); mtOk {
-		log.Printf("[INFO] Setting Maintenance Window Time to : %s", mtVal.(string))
-		k8sNodepool.Properties.MaintenanceWindow.Time = mtVal.(string)
-	}
-
-	if mdVal, mdOk := d.GetOk("maintenance_window.0.day_of_the_week"); mdOk {
-		k8sNodepool.Properties.MaintenanceWindow.DayOfTheWeek = mdVal.(string)
-	}
+	k8sNodepool.Properties.MaintenanceWindow = expandMaintenanceWindow(d)
 
 	if lansVal, lansOK := d.GetOk("lans"); lansOK {
 		if lansVal.([]interface{}) != nil {
@@ -211,32 +251,37 @@ func resourcek8sNodePoolCreate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	createdNodepool, err := client.CreateKubernetesNodePool(d.Get("k8s_cluster_id").(string), k8sNodepool)
+	nodePool := k8sNodePoolWithExtras{
+		Properties: k8sNodePoolPropertiesWithExtras{
+			KubernetesNodePoolProperties: *k8sNodepool.Properties,
+			GatewayIP:                    d.Get("gateway_ip").(string),
+			Labels:                       expandStringMap(d.Get("labels")),
+			Annotations:                  expandStringMap(d.Get("annotations")),
+		},
+	}
+	if autoScaling := k8sNodepool.Properties.AutoScaling; autoScaling != nil {
+		nodePool.Properties.AutoScaling = &k8sNodePoolAutoScaling{
+			MinNodeCount: autoScaling.MinNodeCount,
+			MaxNodeCount: autoScaling.MaxNodeCount,
+		}
+	}
+
+	createdNodepool, err := createK8sNodePoolWithExtras(client, d.Get("k8s_cluster_id").(string), nodePool)
 
 	if err != nil {
 		d.SetId("")
-		return fmt.Errorf("Error creating k8s node pool: %s", err)
+		return fmt.Errorf("Error creating k8s node pool: %w", err)
 	}
 
 	d.SetId(createdNodepool.ID)
 
 	log.Printf("[INFO] Successfully created k8s node pool: %s", d.Id())
 
-	for {
-		log.Printf("[INFO] Waiting for k8s node pool %s to be ready...", d.Id())
-		time.Sleep(10 * time.Second)
-
-		nodepoolReady, rsErr := k8sNodepoolReady(client, d)
-
-		if rsErr != nil {
-			return fmt.Errorf("Error while checking readiness status of k8s node pool %s: %s", d.Id(), rsErr)
-		}
-
-		if nodepoolReady && rsErr == nil {
-			log.Printf("[INFO] k8s node pool ready: %s", d.Id())
-			break
-		}
+	log.Printf("[INFO] Waiting for k8s node pool %s to be ready...", d.Id())
+	if _, err := k8sNodepoolStateChangeConf(client, d, schema.TimeoutCreate).WaitForState(); err != nil {
+		return fmt.Errorf("Error while waiting for k8s node pool %s to be ready: %w", d.Id(), err)
 	}
+	log.Printf("[INFO] k8s node pool ready: %s", d.Id())
 
 	return resourcek8sNodePoolRead(d, meta)
 }
@@ -244,7 +289,7 @@ func resourcek8sNodePoolCreate(d *schema.ResourceData, meta interface{}) error {
 func resourcek8sNodePoolRead(d *schema.ResourceData, meta interface{}) error {
 
 	client := meta.(*profitbricks.Client)
-	k8sNodepool, err := client.GetKubernetesNodePool(d.Get("k8s_cluster_id").(string), d.Id())
+	k8sNodepool, err := getK8sNodePoolWithExtras(client, d.Get("k8s_cluster_id").(string), d.Id())
 
 	if err != nil {
 		log.Printf("[INFO] Resource %s not found: %+v", d.Id(), err)
@@ -254,6 +299,7 @@ func resourcek8sNodePoolRead(d *schema.ResourceData, meta interface{}) error {
 				return nil
 			}
 		}
+		return fmt.Errorf("Error while fetching k8s node pool %s: %w", d.Id(), err)
 	}
 
 	log.Printf("[INFO] Successfully retreived k8s node pool %s: %+v", d.Id(), k8sNodepool)
@@ -269,6 +315,17 @@ func resourcek8sNodePoolRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("cores_count", k8sNodepool.Properties.CoresCount)
 	d.Set("ram_size", k8sNodepool.Properties.RAMSize)
 	d.Set("storage_size", k8sNodepool.Properties.StorageSize)
+	d.Set("gateway_ip", k8sNodepool.Properties.GatewayIP)
+	if err := d.Set("labels", k8sNodepool.Properties.Labels); err != nil {
+		return err
+	}
+	if err := d.Set("annotations", k8sNodepool.Properties.Annotations); err != nil {
+		return err
+	}
+
+	if err := d.Set("maintenance_window", flattenMaintenanceWindow(k8sNodepool.Properties.MaintenanceWindow)); err != nil {
+		return err
+	}
 
 	if k8sNodepool.Properties.AutoScaling != nil && (k8sNodepool.Properties.AutoScaling.MinNodeCount != 0 && k8sNodepool.Properties.AutoScaling.MaxNodeCount != 0) {
 		d.Set("auto_scaling", []map[string]uint32{
@@ -278,145 +335,100 @@ func resourcek8sNodePoolRead(d *schema.ResourceData, meta interface{}) error {
 			},
 		})
 		log.Printf("[INFO] Setting AutoScaling for k8s node pool %s to %+v...", d.Id(), k8sNodepool.Properties.AutoScaling)
+	} else {
+		d.Set("auto_scaling", []map[string]uint32{})
 	}
 
-	return nil
-}
-
-func resourcek8sNodePoolUpdate(d *schema.ResourceData, meta interface{}) error {
-
-	client := meta.(*profitbricks.Client)
-	request := profitbricks.KubernetesNodePool{}
-
-	request.Properties = &profitbricks.KubernetesNodePoolProperties{
-		NodeCount: uint32(d.Get("node_count").(int)),
+	k8sNodes, err := client.ListKubernetesNodes(d.Get("k8s_cluster_id").(string), d.Id())
+	if err != nil {
+		return fmt.Errorf("Error while fetching the nodes of k8s node pool %s: %w", d.Id(), err)
 	}
 
-	if d.HasChange("k8s_version") {
-		oldk8sVersion, newk8sVersion := d.GetChange("k8s_version")
-		log.Printf("[INFO] k8s pool k8s version changed from %+v to %+v", oldk8sVersion, newk8sVersion)
-		if newk8sVersion != nil {
-			request.Properties.K8sVersion = newk8sVersion.(string)
-		}
+	if err := d.Set("nodes", flattenK8sNodes(k8sNodes.Items)); err != nil {
+		return err
 	}
 
-	if d.HasChange("auto_scaling.0") {
-		_, newAs := d.GetChange("auto_scaling.0")
-		if newAs.(map[string]interface{}) != nil {
-			updateAutoscaling := false
-			autoScaling := &profitbricks.AutoScaling{
-				MinNodeCount: uint32(d.Get("auto_scaling.0.min_node_count").(int)),
-				MaxNodeCount: uint32(d.Get("auto_scaling.0.max_node_count").(int)),
-			}
-
-			if d.HasChange("auto_scaling.0.min_node_count") {
-				oldMinNodes, newMinNodes := d.GetChange("auto_scaling.0.min_node_count")
-				if newMinNodes != 0 {
-					log.Printf("[INFO] k8s node pool autoscaling min # of nodes changed from %+v to %+v", oldMinNodes, newMinNodes)
-					updateAutoscaling = true
-					autoScaling.MinNodeCount = uint32(newMinNodes.(int))
-				}
-			}
-
-			if d.HasChange("auto_scaling.0.max_node_count") {
-				oldMaxNodes, newMaxNodes := d.GetChange("auto_scaling.0.max_node_count")
-				if newMaxNodes != 0 {
-					log.Printf("[INFO] k8s node pool autoscaling max # of nodes changed from %+v to %+v", oldMaxNodes, newMaxNodes)
-					updateAutoscaling = true
-					autoScaling.MaxNodeCount = uint32(newMaxNodes.(int))
-				}
-			}
+	return nil
+}
 
-			if updateAutoscaling == true {
-				request.Properties.AutoScaling = autoScaling
-			}
+// flattenK8sNodes returns the nodes of a node pool as set in the nodes attribute
+func flattenK8sNodes(k8sNodes []profitbricks.KubernetesNode) []interface{} {
+	nodes := []interface{}{}
+	for _, k8sNode := range k8sNodes {
+		node := map[string]interface{}{
+			"id": k8sNode.ID,
 		}
-	}
-
-	if d.HasChange("node_count") {
-		updateNodeCount := true
-
-		if d.Get("auto_scaling.0").(map[string]interface{}) != nil && (d.Get("auto_scaling.0.min_node_count").(int) != 0 || d.Get("auto_scaling.0.max_node_count").(int) != 0) {
-
-			updateNodeCount = false
-			np, npErr := client.GetKubernetesNodePool(d.Get("k8s_cluster_id").(string), d.Id())
-			if npErr != nil {
-				return fmt.Errorf("Error retrieving k8s node pool %q: %s", d.Id(), npErr)
-			}
-
-			log.Printf("[INFO] Setting node_count for node pool %q from server from %d to %d instead of due to autoscaling %+v", d.Id(), uint32(d.Get("node_count").(int)), np.Properties.NodeCount, d.Get("auto_scaling.0"))
-			request.Properties.NodeCount = uint32(np.Properties.NodeCount)
+		if k8sNode.Properties != nil {
+			node["name"] = k8sNode.Properties.Name
+			node["public_ip"] = k8sNode.Properties.PublicIP
+			node["k8s_version"] = k8sNode.Properties.K8sVersion
 		}
-
-		if updateNodeCount {
-			oldNc, newNc := d.GetChange("node_count")
-			log.Printf("[INFO] k8s node pool node_count changed from %+v to %+v", oldNc, newNc)
-			if oldNc.(int) != newNc.(int) {
-				request.Properties.NodeCount = uint32(newNc.(int))
-			}
+		if k8sNode.Metadata != nil {
+			node["state"] = k8sNode.Metadata.State
 		}
+		nodes = append(nodes, node)
 	}
+	return nodes
+}
 
-	if d.HasChange("lans") {
-		oldLANs, newLANs := d.GetChange("lans")
-		if newLANs.([]interface{}) != nil {
-			updateLans := false
-
-			lans := []profitbricks.KubernetesNodePoolLAN{}
-
-			for lanIndex := range newLANs.([]interface{}) {
-				if lanID, lanIDOk := d.GetOk(fmt.Sprintf("lans.%d", lanIndex)); lanIDOk {
-					log.Printf("[INFO] Adding k8s node pool to LAN %+v...", lanID)
-					lans = append(lans, profitbricks.KubernetesNodePoolLAN{ID: uint32(lanID.(int))})
-				}
-			}
+func resourcek8sNodePoolUpdate(d *schema.ResourceData, meta interface{}) error {
 
-			if len(lans) > 0 {
-				updateLans = true
-			}
+	client := meta.(*profitbricks.Client)
+	request := k8sNodePoolWithExtras{}
+
+	// the node pool properties are replaced by the request, so all the ones which can be updated are sent,
+	// changed or not. Labels and annotations left out are removed, without the auto_scaling block both of
+	// its counts are 0, which turns auto scaling off
+	lans := []profitbricks.KubernetesNodePoolLAN{}
+	for _, lanID := range d.Get("lans").([]interface{}) {
+		lans = append(lans, profitbricks.KubernetesNodePoolLAN{ID: uint32(lanID.(int))})
+	}
+	request.Properties = k8sNodePoolPropertiesWithExtras{
+		KubernetesNodePoolProperties: profitbricks.KubernetesNodePoolProperties{
+			Name:              d.Get("name").(string),
+			K8sVersion:        d.Get("k8s_version").(string),
+			NodeCount:         uint32(d.Get("node_count").(int)),
+			LANs:              &lans,
+			MaintenanceWindow: expandMaintenanceWindow(d),
+		},
+		AutoScaling: &k8sNodePoolAutoScaling{
+			MinNodeCount: uint32(d.Get("auto_scaling.0.min_node_count").(int)),
+			MaxNodeCount: uint32(d.Get("auto_scaling.0.max_node_count").(int)),
+		},
+		GatewayIP:   d.Get("gateway_ip").(string),
+		Labels:      expandStringMap(d.Get("labels")),
+		Annotations: expandStringMap(d.Get("annotations")),
+	}
 
-			if updateLans == true {
-				log.Printf("[INFO] k8s node pool LANs changed from %+v to %+v", oldLANs, newLANs)
-				request.Properties.LANs = &lans
-			}
+	for _, key := range []string{"k8s_version", "auto_scaling", "lans", "maintenance_window", "labels", "annotations"} {
+		if d.HasChange(key) {
+			oldValue, newValue := d.GetChange(key)
+			log.Printf("[INFO] k8s node pool %s changed from %+v to %+v", key, oldValue, newValue)
 		}
 	}
 
-	if d.HasChange("maintenance_window.0") {
-
-		_, newMw := d.GetChange("maintenance_window.0")
-
-		if newMw.(map[string]interface{}) != nil {
-
-			updateMaintenanceWindow := false
-			maintenanceWindow := &profitbricks.MaintenanceWindow{
-				DayOfTheWeek: d.Get("maintenance_window.0.day_of_the_week").(string),
-				Time:         d.Get("maintenance_window.0.time").(string),
-			}
-
-			if d.HasChange("maintenance_window.0.day_of_the_week") {
-
-				oldMd, newMd := d.GetChange("maintenance_window.0.day_of_the_week")
-				if newMd.(string) != "" {
-					log.Printf("[INFO] k8s node pool maintenance window DOW changed from %+v to %+v", oldMd, newMd)
-					updateMaintenanceWindow = true
-					maintenanceWindow.DayOfTheWeek = newMd.(string)
-				}
-			}
-
-			if d.HasChange("maintenance_window.0.time") {
-				oldMt, newMt := d.GetChange("maintenance_window.0.time")
-				if newMt.(string) != "" {
-					log.Printf("[INFO] k8s node pool maintenance window time changed from %+v to %+v", oldMt, newMt)
-					updateMaintenanceWindow = true
-					maintenanceWindow.Time = newMt.(string)
-				}
-			}
+	waitForNodes := false
+	if minNodes, maxNodes, ok := k8sNodePoolAutoScalingRange(d); ok {
+		// the autoscaler owns the node count, the current one is kept unless it is out of the new range
+		np, npErr := client.GetKubernetesNodePool(d.Get("k8s_cluster_id").(string), d.Id())
+		if npErr != nil {
+			return fmt.Errorf("Error retrieving k8s node pool %q: %w", d.Id(), npErr)
+		}
 
-			if updateMaintenanceWindow == true {
-				request.Properties.MaintenanceWindow = maintenanceWindow
-			}
+		nodeCount := int(np.Properties.NodeCount)
+		if nodeCount < minNodes {
+			nodeCount = minNodes
 		}
+		if nodeCount > maxNodes {
+			nodeCount = maxNodes
+		}
+		log.Printf("[INFO] Setting node_count for node pool %q to %d instead of %d due to autoscaling %+v", d.Id(), nodeCount, d.Get("node_count").(int), d.Get("auto_scaling.0"))
+		request.Properties.NodeCount = uint32(nodeCount)
+		waitForNodes = nodeCount != int(np.Properties.NodeCount)
+	} else if d.HasChange("node_count") {
+		oldNc, newNc := d.GetChange("node_count")
+		log.Printf("[INFO] k8s node pool node_count changed from %+v to %+v", oldNc, newNc)
+		waitForNodes = oldNc.(int) != newNc.(int)
 	}
 
 	b, jErr := json.Marshal(request)
@@ -425,7 +437,7 @@ func resourcek8sNodePoolUpdate(d *schema.ResourceData, meta interface{}) error {
 		log.Printf("[INFO] Update req: %s", string(b))
 	}
 
-	_, err := client.UpdateKubernetesNodePool(d.Get("k8s_cluster_id").(string), d.Id(), request)
+	_, err := updateK8sNodePoolWithExtras(client, d.Get("k8s_cluster_id").(string), d.Id(), request)
 
 	if err != nil {
 		if apiError, ok := err.(profitbricks.ApiError); ok {
@@ -433,25 +445,24 @@ func resourcek8sNodePoolUpdate(d *schema.ResourceData, meta interface{}) error {
 				d.SetId("")
 				return nil
 			}
-			return fmt.Errorf("Error while updating k8s node pool: %s", err)
+			return fmt.Errorf("Error while updating k8s node pool: %w", err)
 		}
-		return fmt.Errorf("Error while updating k8s node pool %s: %s", d.Id(), err)
+		return fmt.Errorf("Error while updating k8s node pool %s: %w", d.Id(), err)
 	}
 
-	for {
-		log.Printf("[INFO] Waiting for k8s node pool %s to be ready...", d.Id())
-		time.Sleep(10 * time.Second)
-
-		nodepoolReady, rsErr := k8sNodepoolReady(client, d)
-
-		if rsErr != nil {
-			return fmt.Errorf("Error while checking readiness status of k8s node pool %s: %s", d.Id(), rsErr)
-		}
+	log.Printf("[INFO] Waiting for k8s node pool %s to be ready...", d.Id())
+	if _, err := k8sNodepoolStateChangeConf(client, d, schema.TimeoutUpdate).WaitForState(); err != nil {
+		return fmt.Errorf("Error while waiting for k8s node pool %s to be ready: %w", d.Id(), err)
+	}
+	log.Printf("[INFO] k8s node pool ready: %s", d.Id())
 
-		if nodepoolReady && rsErr == nil {
-			log.Printf("[INFO] k8s node pool ready: %s", d.Id())
-			break
+	if waitForNodes {
+		// the node pool may still be ACTIVE right after the request, nodes are removed or added afterwards
+		log.Printf("[INFO] Waiting for k8s node pool %s to have %d nodes...", d.Id(), request.Properties.NodeCount)
+		if _, err := k8sNodePoolNodesStateChangeConf(client, d, request.Properties.NodeCount, schema.TimeoutUpdate).WaitForState(); err != nil {
+			return fmt.Errorf("Error while waiting for k8s node pool %s to be scaled to %d nodes: %w", d.Id(), request.Properties.NodeCount, err)
 		}
+		log.Printf("[INFO] k8s node pool %s scaled to %d nodes", d.Id(), request.Properties.NodeCount)
 	}
 
 	return resourcek8sNodePoolRead(d, meta)
@@ -468,51 +479,198 @@ func resourcek8sNodePoolDelete(d *schema.ResourceData, meta interface{}) error {
 				d.SetId("")
 				return nil
 			}
-			return fmt.Errorf("Error while deleting k8s node pool: %s", err)
+			return fmt.Errorf("Error while deleting k8s node pool: %w", err)
 		}
 
-		return fmt.Errorf("Error while deleting k8s node pool %s: %s", d.Id(), err)
+		return fmt.Errorf("Error while deleting k8s node pool %s: %w", d.Id(), err)
 	}
 
-	for {
-		log.Printf("[INFO] Waiting for k8s node pool %s to be deleted...", d.Id())
-		time.Sleep(10 * time.Second)
-
-		nodepoolDeleted, dsErr := k8sNodepoolDeleted(client, d)
-
-		if dsErr != nil {
-			return fmt.Errorf("Error while checking deletion status of k8s node pool %s: %s", d.Id(), dsErr)
-		}
+	log.Printf("[INFO] Waiting for k8s node pool %s to be deleted...", d.Id())
+	deleteConf := &resource.StateChangeConf{
+		Pending:    []string{"DESTROYING", "ACTIVE", "BUSY"},
+		Target:     []string{"DELETED"},
+		Refresh:    k8sNodepoolDeletedRefreshFunc(client, d.Get("k8s_cluster_id").(string), d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		MinTimeout: pollInterval(client, 10*time.Second),
+		Delay:      pollInterval(client, 10*time.Second),
+	}
 
-		if nodepoolDeleted && dsErr == nil {
-			log.Printf("[INFO] Successfully deleted k8s node pool: %s", d.Id())
-			break
-		}
+	if _, err := deleteConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error while waiting for k8s node pool %s to be deleted: %w", d.Id(), err)
 	}
+	log.Printf("[INFO] Successfully deleted k8s node pool: %s", d.Id())
 
 	d.SetId("")
 	return nil
 }
 
-func k8sNodepoolReady(client *profitbricks.Client, d *schema.ResourceData) (bool, error) {
-	subjectNodepool, err := client.GetKubernetesNodePool(d.Get("k8s_cluster_id").(string), d.Id())
+// k8sNodePoolAutoScaling sends both counts even when they are 0, which the SDK's AutoScaling omits
+type k8sNodePoolAutoScaling struct {
+	MinNodeCount uint32 `json:"minNodeCount"`
+	MaxNodeCount uint32 `json:"maxNodeCount"`
+}
+
+type k8sNodePoolPropertiesWithExtras struct {
+	profitbricks.KubernetesNodePoolProperties
+	AutoScaling *k8sNodePoolAutoScaling `json:"autoScaling,omitempty"`
+	GatewayIP   string                  `json:"gatewayIp,omitempty"`
+	Labels      map[string]string       `json:"labels"`
+	Annotations map[string]string       `json:"annotations"`
+}
+
+type k8sNodePoolWithExtras struct {
+	ID         string                          `json:"id,omitempty"`
+	Metadata   *profitbricks.Metadata          `json:"metadata,omitempty"`
+	Properties k8sNodePoolPropertiesWithExtras `json:"properties"`
+}
 
+func getK8sNodePoolWithExtras(client *profitbricks.Client, clusterId string, nodePoolId string) (*k8sNodePoolWithExtras, error) {
+	ret := &k8sNodePoolWithExtras{}
+	err := client.Get(fmt.Sprintf("/k8s/%s/nodepools/%s", clusterId, nodePoolId), ret, http.StatusOK)
+	return ret, err
+}
+
+func createK8sNodePoolWithExtras(client *profitbricks.Client, clusterId string, nodePool k8sNodePoolWithExtras) (*k8sNodePoolWithExtras, error) {
+	ret := &k8sNodePoolWithExtras{}
+	err := client.PostAcc(fmt.Sprintf("/k8s/%s/nodepools", clusterId), nodePool, ret)
+	return ret, err
+}
+
+func updateK8sNodePoolWithExtras(client *profitbricks.Client, clusterId string, nodePoolId string, nodePool k8sNodePoolWithExtras) (*k8sNodePoolWithExtras, error) {
+	ret := &k8sNodePoolWithExtras{}
+	err := client.PutAcc(fmt.Sprintf("/k8s/%s/nodepools/%s", clusterId, nodePoolId), nodePool, ret)
+	return ret, err
+}
+
+// k8sNodePoolAutoScalingRange returns the node counts auto scaling keeps a node pool in, ok is false when it is off
+func k8sNodePoolAutoScalingRange(d *schema.ResourceData) (minNodes int, maxNodes int, ok bool) {
+	minNodes = d.Get("auto_scaling.0.min_node_count").(int)
+	maxNodes = d.Get("auto_scaling.0.max_node_count").(int)
+	return minNodes, maxNodes, minNodes != 0 && maxNodes != 0
+}
+
+// resourcek8sNodePoolCustomizeDiff checks the auto scaling range of a node pool, and that a new node pool
+// starts with a node count in it
+func resourcek8sNodePoolCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	if len(diff.Get("auto_scaling").([]interface{})) == 0 {
+		return nil
+	}
+	if !diff.NewValueKnown("auto_scaling.0.min_node_count") || !diff.NewValueKnown("auto_scaling.0.max_node_count") {
+		return nil
+	}
+
+	minNodes := diff.Get("auto_scaling.0.min_node_count").(int)
+	maxNodes := diff.Get("auto_scaling.0.max_node_count").(int)
+	if maxNodes < minNodes {
+		return fmt.Errorf("max_node_count (%d) cannot be lower than min_node_count (%d)", maxNodes, minNodes)
+	}
+
+	if diff.Id() == "" {
+		if nodeCount, ok := diff.GetOk("node_count"); ok && (nodeCount.(int) < minNodes || nodeCount.(int) > maxNodes) {
+			return fmt.Errorf("node_count (%d) has to be between min_node_count (%d) and max_node_count (%d)", nodeCount.(int), minNodes, maxNodes)
+		}
+	}
+	return nil
+}
+
+// suppressNodeCountDiffWithAutoScaling keeps the node count the autoscaler picked as long as it is in the
+// auto scaling range
+func suppressNodeCountDiffWithAutoScaling(k, old, new string, d *schema.ResourceData) bool {
+	if d.Id() == "" {
+		return false
+	}
+	minNodes, maxNodes, ok := k8sNodePoolAutoScalingRange(d)
+	if !ok {
+		return false
+	}
+	nodeCount, err := strconv.Atoi(old)
 	if err != nil {
-		return true, fmt.Errorf("Error checking k8s node pool status: %s", err)
+		return false
 	}
-	return subjectNodepool.Metadata.State == "ACTIVE", nil
+	return nodeCount >= minNodes && nodeCount <= maxNodes
 }
 
-func k8sNodepoolDeleted(client *profitbricks.Client, d *schema.ResourceData) (bool, error) {
-	_, err := client.GetKubernetesNodePool(d.Get("k8s_cluster_id").(string), d.Id())
+// k8sNodepoolStateChangeConf waits for a k8s node pool to reach the ACTIVE state
+func k8sNodepoolStateChangeConf(client *profitbricks.Client, d *schema.ResourceData, timeoutType string) *resource.StateChangeConf {
+	return &resource.StateChangeConf{
+		Pending:    []string{"DEPLOYING", "UPDATING", "BUSY"},
+		Target:     []string{"ACTIVE"},
+		Refresh:    k8sNodepoolStateRefreshFunc(client, d.Get("k8s_cluster_id").(string), d.Id()),
+		Timeout:    d.Timeout(timeoutType),
+		MinTimeout: pollInterval(client, 10*time.Second),
+		Delay:      pollInterval(client, 10*time.Second),
+	}
+}
 
-	if err != nil {
-		if apiError, ok := err.(profitbricks.ApiError); ok {
-			if apiError.HttpStatusCode() == 404 {
-				return true, nil
+// k8sNodepoolStateRefreshFunc reports the metadata state of a k8s node pool
+func k8sNodepoolStateRefreshFunc(client *profitbricks.Client, clusterID, nodePoolID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		nodePool, err := client.GetKubernetesNodePool(clusterID, nodePoolID)
+		if err != nil {
+			return nil, "", fmt.Errorf("Error checking k8s node pool status: %w", err)
+		}
+		if nodePool.Metadata == nil {
+			return nodePool, "BUSY", nil
+		}
+		if nodePool.Metadata.State == "FAILED" {
+			return nil, "", fmt.Errorf("k8s node pool %s is in a FAILED state", nodePoolID)
+		}
+		return nodePool, nodePool.Metadata.State, nil
+	}
+}
+
+// k8sNodepoolDeletedRefreshFunc reports DELETED once a k8s node pool can no longer be found
+func k8sNodepoolDeletedRefreshFunc(client *profitbricks.Client, clusterID, nodePoolID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		nodePool, err := client.GetKubernetesNodePool(clusterID, nodePoolID)
+		if err != nil {
+			if apiError, ok := err.(profitbricks.ApiError); ok && apiError.HttpStatusCode() == 404 {
+				return nodePoolID, "DELETED", nil
+			}
+			return nil, "", fmt.Errorf("Error checking k8s node pool deletion status: %w", err)
+		}
+		if nodePool.Metadata == nil {
+			return nodePool, "BUSY", nil
+		}
+		return nodePool, nodePool.Metadata.State, nil
+	}
+}
+
+// k8sNodePoolNodesStateChangeConf waits for a k8s node pool to be ACTIVE with nodeCount nodes, all of them ACTIVE
+func k8sNodePoolNodesStateChangeConf(client *profitbricks.Client, d *schema.ResourceData, nodeCount uint32, timeoutType string) *resource.StateChangeConf {
+	return &resource.StateChangeConf{
+		Pending:    []string{"UPDATING"},
+		Target:     []string{"ACTIVE"},
+		Refresh:    k8sNodePoolNodesRefreshFunc(client, d.Get("k8s_cluster_id").(string), d.Id(), nodeCount),
+		Timeout:    d.Timeout(timeoutType),
+		MinTimeout: pollInterval(client, 10*time.Second),
+		Delay:      pollInterval(client, 10*time.Second),
+	}
+}
+
+// k8sNodePoolNodesRefreshFunc reports ACTIVE once a k8s node pool and all of its nodes are ACTIVE and there are
+// nodeCount of them, UPDATING otherwise
+func k8sNodePoolNodesRefreshFunc(client *profitbricks.Client, clusterID, nodePoolID string, nodeCount uint32) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		nodePool, state, err := k8sNodepoolStateRefreshFunc(client, clusterID, nodePoolID)()
+		if err != nil || state != "ACTIVE" {
+			return nodePool, "UPDATING", err
+		}
+
+		nodes, err := client.ListKubernetesNodes(clusterID, nodePoolID)
+		if err != nil {
+			return nil, "", fmt.Errorf("Error checking the nodes of k8s node pool %s: %w", nodePoolID, err)
+		}
+		if uint32(len(nodes.Items)) != nodeCount {
+			log.Printf("[DEBUG] k8s node pool %s has %d nodes, waiting for %d", nodePoolID, len(nodes.Items), nodeCount)
+			return nodes, "UPDATING", nil
+		}
+		for _, node := range nodes.Items {
+			if node.Metadata != nil && node.Metadata.State != "ACTIVE" {
+				log.Printf("[DEBUG] Node %s of k8s node pool %s is %s", node.ID, nodePoolID, node.Metadata.State)
+				return nodes, "UPDATING", nil
 			}
-			return true, fmt.Errorf("Error checking k8s node pool deletion status: %s", err)
 		}
+		return nodes, "ACTIVE", nil
 	}
-	return false, nil
 }
@@ -3,9 +3,14 @@ package profitbricks
 import (
 	"fmt"
 	"log"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
 )
@@ -35,12 +40,141 @@ func TestAccProfitBricksk8sNodepool_Basic(t *testing.T) {
 					resource.TestCheckResourceAttr("profitbricks_k8s_node_pool.example", "name", k8sNodepoolName),
 					resource.TestCheckResourceAttr("profitbricks_k8s_node_pool.example", "maintenance_window.0.day_of_the_week", "Tuesday"),
 					resource.TestCheckResourceAttr("profitbricks_k8s_node_pool.example", "maintenance_window.0.time", "11:00:00Z"),
+					resource.TestCheckResourceAttrPair("profitbricks_k8s_node_pool.example", "nodes.#", "profitbricks_k8s_node_pool.example", "node_count"),
+					resource.TestCheckResourceAttr("profitbricks_k8s_node_pool.example", "auto_scaling.0.max_node_count", "3"),
+					resource.TestCheckResourceAttr("profitbricks_k8s_node_pool.example", "labels.%", "1"),
+					resource.TestCheckResourceAttr("profitbricks_k8s_node_pool.example", "labels.example.com/role", "worker"),
+					resource.TestCheckResourceAttr("profitbricks_k8s_node_pool.example", "annotations.note", "managed by terraform"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckProfitBricksk8sNodepoolConfigBasic, k8sNodepoolName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksk8sNodepoolExists("profitbricks_k8s_node_pool.example", &k8sNodepool),
+					resource.TestCheckResourceAttr("profitbricks_k8s_node_pool.example", "auto_scaling.#", "0"),
+					resource.TestCheckResourceAttr("profitbricks_k8s_node_pool.example", "labels.%", "2"),
+					resource.TestCheckResourceAttr("profitbricks_k8s_node_pool.example", "annotations.%", "0"),
 				),
 			},
 		},
 	})
 }
 
+func TestAccProfitBricksk8sNodepool_InvalidAutoScaling(t *testing.T) {
+	config := fmt.Sprintf(testAccCheckProfitBricksk8sNodepoolConfigUpdate, "example")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      strings.Replace(config, "min_node_count = 1", "min_node_count = 4", 1),
+				ExpectError: regexp.MustCompile(`max_node_count \(3\) cannot be lower than min_node_count \(4\)`),
+			},
+			{
+				Config:      strings.Replace(config, "min_node_count = 1", "min_node_count = 0", 1),
+				ExpectError: regexp.MustCompile(`must be at least 1`),
+			},
+			{
+				Config:      strings.Replace(config, "node_count        = 1", "node_count        = 5", 1),
+				ExpectError: regexp.MustCompile(`node_count \(5\) has to be between`),
+			},
+		},
+	})
+}
+
+func TestValidateK8sLabels(t *testing.T) {
+	for _, tc := range []struct {
+		labels map[string]interface{}
+		valid  bool
+	}{
+		{map[string]interface{}{"tier": "backend"}, true},
+		{map[string]interface{}{"example.com/role": "worker", "empty": ""}, true},
+		{map[string]interface{}{"-tier": "backend"}, false},
+		{map[string]interface{}{"Example.com/role": "worker"}, false},
+		{map[string]interface{}{"example.com/": "worker"}, false},
+		{map[string]interface{}{"tier": "back end"}, false},
+		{map[string]interface{}{"tier": strings.Repeat("a", 64)}, false},
+	} {
+		_, errs := validateK8sLabels(tc.labels, "labels")
+		if tc.valid && len(errs) > 0 {
+			t.Errorf("expected %+v to be valid, got %v", tc.labels, errs)
+		}
+		if !tc.valid && len(errs) == 0 {
+			t.Errorf("expected %+v to be invalid", tc.labels)
+		}
+	}
+
+	if _, errs := validateK8sAnnotations(map[string]interface{}{"note": "any value, even with spaces"}, "annotations"); len(errs) > 0 {
+		t.Errorf("expected annotation values not to be restricted, got %v", errs)
+	}
+}
+
+func TestSuppressNodeCountDiffWithAutoScaling(t *testing.T) {
+	autoScaling := []interface{}{
+		map[string]interface{}{"min_node_count": 2, "max_node_count": 4},
+	}
+
+	for _, tc := range []struct {
+		raw      map[string]interface{}
+		old      string
+		suppress bool
+	}{
+		{map[string]interface{}{"auto_scaling": autoScaling, "node_count": 2}, "3", true},
+		{map[string]interface{}{"auto_scaling": autoScaling, "node_count": 2}, "4", true},
+		{map[string]interface{}{"auto_scaling": autoScaling, "node_count": 2}, "5", false},
+		{map[string]interface{}{"node_count": 2}, "3", false},
+	} {
+		d := schema.TestResourceDataRaw(t, resourcek8sNodePool().Schema, tc.raw)
+		d.SetId("nodepool-id")
+		if suppress := suppressNodeCountDiffWithAutoScaling("node_count", tc.old, "2", d); suppress != tc.suppress {
+			t.Errorf("expected %t for %s nodes with %+v, got %t", tc.suppress, tc.old, tc.raw, suppress)
+		}
+	}
+}
+
+func TestK8sNodePoolNodesStateChangeConf_scaleDown(t *testing.T) {
+	nodeCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/k8s/cluster-id/nodepools/nodepool-id":
+			w.Write([]byte(`{"id": "nodepool-id", "metadata": {"state": "ACTIVE"}, "properties": {"nodeCount": 1}}`))
+		case "/k8s/cluster-id/nodepools/nodepool-id/nodes":
+			nodeCalls++
+			if nodeCalls < 3 {
+				// the removed node is still there
+				w.Write([]byte(`{"items": [{"id": "node-1", "metadata": {"state": "ACTIVE"}, "properties": {"name": "node-1"}}, {"id": "node-2", "metadata": {"state": "TERMINATING"}, "properties": {"name": "node-2"}}]}`))
+				return
+			}
+			w.Write([]byte(`{"items": [{"id": "node-1", "metadata": {"state": "ACTIVE"}, "properties": {"name": "node-1"}}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	config := Config{Token: "token", Endpoint: server.URL, PollInterval: 1}
+	client, err := config.Client("0.12")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourcek8sNodePool().Schema, map[string]interface{}{
+		"k8s_cluster_id": "cluster-id",
+	})
+	d.SetId("nodepool-id")
+
+	if _, err := k8sNodePoolNodesStateChangeConf(client, d, 1, schema.TimeoutUpdate).WaitForState(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if nodeCalls != 3 {
+		t.Fatalf("expected 3 calls listing the nodes, got %d", nodeCalls)
+	}
+}
+
 func testAccCheckDProfitBricksk8sNodepoolDestroyCheck(s *terraform.State) error {
 	client := testAccProvider.Meta().(*profitbricks.Client)
 
@@ -124,6 +258,10 @@ resource "profitbricks_k8s_node_pool" "example" {
   cores_count       = 2
   ram_size          = 2048
   storage_size      = 40
+  labels = {
+    "example.com/role" = "worker"
+    tier               = "backend"
+  }
 }`
 
 const testAccCheckProfitBricksk8sNodepoolConfigUpdate = `
@@ -162,4 +300,10 @@ resource "profitbricks_k8s_node_pool" "example" {
   cores_count       = 2
   ram_size          = 2048
   storage_size      = 40
+  labels = {
+    "example.com/role" = "worker"
+  }
+  annotations = {
+    note = "managed by terraform"
+  }
 }`
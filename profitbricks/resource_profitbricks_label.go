@@ -0,0 +1,137 @@
+package profitbricks
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
+)
+
+func resourceProfitBricksLabel() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceProfitBricksLabelCreate,
+		Read:   resourceProfitBricksLabelRead,
+		Update: resourceProfitBricksLabelUpdate,
+		Delete: resourceProfitBricksLabelDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceProfitBricksLabelImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"resource_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The type of the labeled resource: datacenter, server, volume, ipblock or snapshot.",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if _, err := labelsPath(v.(string), "-", "-"); err != nil {
+						errors = append(errors, err)
+					}
+					return
+				},
+			},
+			"resource_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"datacenter_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The data center of the labeled server or volume.",
+			},
+			"key": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"value": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+		Timeouts: &resourceDefaultTimeouts,
+	}
+}
+
+func resourceProfitBricksLabelPath(d *schema.ResourceData) (string, error) {
+	return labelsPath(d.Get("resource_type").(string), d.Get("datacenter_id").(string), d.Get("resource_id").(string))
+}
+
+func resourceProfitBricksLabelCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+	path, err := resourceProfitBricksLabelPath(d)
+	if err != nil {
+		return err
+	}
+
+	key := d.Get("key").(string)
+	label, err := createLabel(client, path, key, d.Get("value").(string))
+	if err != nil {
+		return fmt.Errorf("An error occured while creating label %s on %s: %w", key, path, err)
+	}
+
+	if label.ID != "" {
+		d.SetId(label.ID)
+	} else {
+		d.SetId(fmt.Sprintf("%s/%s", path, key))
+	}
+	log.Printf("[INFO] Created label %s", d.Id())
+
+	return resourceProfitBricksLabelRead(d, meta)
+}
+
+func resourceProfitBricksLabelRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+	path, err := resourceProfitBricksLabelPath(d)
+	if err != nil {
+		return err
+	}
+
+	label, err := getLabel(client, path, d.Get("key").(string))
+	if err != nil {
+		if apiError, ok := err.(profitbricks.ApiError); ok {
+			if apiError.HttpStatusCode() == 404 {
+				d.SetId("")
+				return nil
+			}
+		}
+		return fmt.Errorf("An error occured while fetching label %s %w", d.Id(), err)
+	}
+
+	d.Set("key", label.Properties.Key)
+	d.Set("value", label.Properties.Value)
+	return nil
+}
+
+func resourceProfitBricksLabelUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+	path, err := resourceProfitBricksLabelPath(d)
+	if err != nil {
+		return err
+	}
+
+	if _, err := updateLabel(client, path, d.Get("key").(string), d.Get("value").(string)); err != nil {
+		return fmt.Errorf("An error occured while updating label %s %w", d.Id(), err)
+	}
+
+	return resourceProfitBricksLabelRead(d, meta)
+}
+
+func resourceProfitBricksLabelDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+	path, err := resourceProfitBricksLabelPath(d)
+	if err != nil {
+		return err
+	}
+
+	if err := deleteLabel(client, path, d.Get("key").(string)); err != nil {
+		if apiError, ok := err.(profitbricks.ApiError); !ok || apiError.HttpStatusCode() != 404 {
+			return fmt.Errorf("An error occured while deleting label %s %w", d.Id(), err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
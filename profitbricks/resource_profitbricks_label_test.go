@@ -0,0 +1,185 @@
+package profitbricks
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
+)
+
+func TestAccProfitBricksLabel_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckProfitBricksLabelDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckProfitBricksLabelConfig_basic, "1234"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksLabelExists("profitbricks_label.ipblock"),
+					testAccCheckProfitBricksLabelExists("profitbricks_label.server"),
+					resource.TestCheckResourceAttr("profitbricks_label.ipblock", "value", "1234"),
+					resource.TestCheckResourceAttr("profitbricks_label.server", "value", "infra"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckProfitBricksLabelConfig_basic, "5678"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksLabelExists("profitbricks_label.ipblock"),
+					resource.TestCheckResourceAttr("profitbricks_label.ipblock", "value", "5678"),
+				),
+			},
+			{
+				ResourceName: "profitbricks_label.server",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["profitbricks_label.server"]
+					return fmt.Sprintf("server/%s/%s/team", rs.Primary.Attributes["datacenter_id"], rs.Primary.Attributes["resource_id"]), nil
+				},
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "profitbricks_label.server",
+				ImportState:   true,
+				ImportStateId: "server/team",
+				ExpectError:   regexp.MustCompile("Invalid import id"),
+			},
+		},
+	})
+}
+
+func TestAccProfitBricksLabel_MissingDatacenter(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckProfitBricksLabelConfig_missingDatacenter,
+				ExpectError: regexp.MustCompile("datacenter_id is required for labels of a volume"),
+			},
+		},
+	})
+}
+
+func testAccCheckProfitBricksLabelDestroyCheck(s *terraform.State) error {
+	client := testAccProvider.Meta().(*profitbricks.Client)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "profitbricks_label" {
+			continue
+		}
+
+		path, err := labelsPath(rs.Primary.Attributes["resource_type"], rs.Primary.Attributes["datacenter_id"], rs.Primary.Attributes["resource_id"])
+		if err != nil {
+			return err
+		}
+
+		_, err = getLabel(client, path, rs.Primary.Attributes["key"])
+		if apiError, ok := err.(profitbricks.ApiError); ok {
+			if apiError.HttpStatusCode() != 404 {
+				return fmt.Errorf("Label still exists %s %s", rs.Primary.ID, apiError)
+			}
+		} else {
+			return fmt.Errorf("Unable to fetch label %s %s", rs.Primary.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckProfitBricksLabelExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*profitbricks.Client)
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("testAccCheckProfitBricksLabelExists: Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		path, err := labelsPath(rs.Primary.Attributes["resource_type"], rs.Primary.Attributes["datacenter_id"], rs.Primary.Attributes["resource_id"])
+		if err != nil {
+			return err
+		}
+
+		label, err := getLabel(client, path, rs.Primary.Attributes["key"])
+		if err != nil {
+			return fmt.Errorf("Error occured while fetching label: %s", rs.Primary.ID)
+		}
+		if label.Properties.Value != rs.Primary.Attributes["value"] {
+			return fmt.Errorf("Label %s has value %q instead of %q", rs.Primary.ID, label.Properties.Value, rs.Primary.Attributes["value"])
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckProfitBricksLabelConfig_basic = `
+resource "profitbricks_datacenter" "foobar" {
+	name       = "label-test"
+	location = "us/las"
+}
+
+resource "profitbricks_ipblock" "webserver_ip" {
+  location = "us/las"
+  size = 1
+  name = "label-test"
+}
+
+resource "profitbricks_lan" "webserver_lan" {
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  public = true
+  name = "public"
+}
+
+resource "profitbricks_server" "webserver" {
+  name = "webserver"
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  cores = 1
+  ram = 1024
+  availability_zone = "ZONE_1"
+  cpu_family = "AMD_OPTERON"
+  image_name ="ubuntu-16.04"
+  image_password = "K3tTj8G14a3EgKyNeeiY"
+  volume {
+    name = "system"
+    size = 5
+    disk_type = "SSD"
+  }
+  nic {
+    lan = "${profitbricks_lan.webserver_lan.id}"
+    dhcp = true
+  }
+}
+
+resource "profitbricks_label" "ipblock" {
+  resource_type = "ipblock"
+  resource_id   = "${profitbricks_ipblock.webserver_ip.id}"
+  key           = "cost_center"
+  value         = "%s"
+}
+
+resource "profitbricks_label" "server" {
+  resource_type = "server"
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  resource_id   = "${profitbricks_server.webserver.id}"
+  key           = "team"
+  value         = "infra"
+}`
+
+const testAccCheckProfitBricksLabelConfig_missingDatacenter = `
+resource "profitbricks_label" "volume" {
+  resource_type = "volume"
+  resource_id   = "00000000-0000-0000-0000-000000000000"
+  key           = "team"
+  value         = "infra"
+}`
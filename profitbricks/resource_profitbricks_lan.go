@@ -3,8 +3,10 @@ package profitbricks
 import (
 	"fmt"
 	"log"
+	"net/http"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
 )
@@ -16,7 +18,7 @@ func resourceProfitBricksLan() *schema.Resource {
 		Update: resourceProfitBricksLanUpdate,
 		Delete: resourceProfitBricksLanDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceProfitBricksResourceImport,
+			State: resourceProfitBricksLanImport,
 		},
 		Schema: map[string]*schema.Schema{
 
@@ -57,6 +59,9 @@ func resourceProfitBricksLanCreate(d *schema.ResourceData, meta interface{}) err
 
 	if d.Get("pcc") != nil && d.Get("pcc").(string) != "" {
 		pccID := d.Get("pcc").(string)
+		if request.Properties.Public {
+			return fmt.Errorf("LAN can not be public and part of the private cross-connect %s at the same time, set public to false", pccID)
+		}
 		log.Printf("[INFO] Setting PCC for LAN %s to %s...", d.Id(), pccID)
 		request.Properties.PCC = pccID
 	}
@@ -65,7 +70,7 @@ func resourceProfitBricksLanCreate(d *schema.ResourceData, meta interface{}) err
 
 	if err != nil {
 		d.SetId("")
-		return fmt.Errorf("An error occured while creating LAN: %s", err)
+		return fmt.Errorf("An error occured while creating LAN: %w", err)
 	}
 
 	log.Printf("[DEBUG] LAN ID: %s", lan.ID)
@@ -76,7 +81,7 @@ func resourceProfitBricksLanCreate(d *schema.ResourceData, meta interface{}) err
 	log.Printf("[INFO] LAN ID: %s", d.Id())
 
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, lan.Headers.Get("Location"), schema.TimeoutCreate).WaitForState()
+	_, errState := waitForRequest(meta, d, lan.Headers.Get("Location"), schema.TimeoutCreate)
 	if errState != nil {
 		if IsRequestFailed(err) {
 			// Request failed, so resource was not created, delete resource from state file
@@ -87,12 +92,12 @@ func resourceProfitBricksLanCreate(d *schema.ResourceData, meta interface{}) err
 
 	for {
 		log.Printf("[INFO] Waiting for LAN %s to be available...", lan.ID)
-		time.Sleep(5 * time.Second)
+		time.Sleep(pollInterval(client, 5*time.Second))
 
 		clusterReady, rsErr := lanAvailable(client, d)
 
 		if rsErr != nil {
-			return fmt.Errorf("Error while checking readiness status of LAN %s: %s", lan.ID, rsErr)
+			return fmt.Errorf("Error while checking readiness status of LAN %s: %w", lan.ID, rsErr)
 		}
 
 		if clusterReady && rsErr == nil {
@@ -117,12 +122,13 @@ func resourceProfitBricksLanRead(d *schema.ResourceData, meta interface{}) error
 			}
 		}
 
-		return fmt.Errorf("An error occured while fetching a LAN %s: %s", d.Id(), err)
+		return fmt.Errorf("An error occured while fetching a LAN %s: %w", d.Id(), err)
 	}
 
 	d.Set("public", lan.Properties.Public)
 	d.Set("name", lan.Properties.Name)
 	d.Set("ip_failover", lan.Properties.IPFailover)
+	d.Set("pcc", lan.Properties.PCC)
 	d.Set("datacenter_id", d.Get("datacenter_id").(string))
 	log.Printf("[INFO] LAN %s found: %+v", d.Id(), lan)
 	return nil
@@ -139,29 +145,49 @@ func resourceProfitBricksLanUpdate(d *schema.ResourceData, meta interface{}) err
 		properties.Name = newValue.(string)
 	}
 
+	if pccID := d.Get("pcc").(string); pccID != "" && properties.Public {
+		return fmt.Errorf("LAN %s can not be public and part of the private cross-connect %s at the same time, set public to false", d.Id(), pccID)
+	}
+
 	if d.HasChange("pcc") {
-		_, newPCC := d.GetChange("pcc")
+		oldPCC, newPCC := d.GetChange("pcc")
 
 		if newPCC != nil && newPCC.(string) != "" {
 			log.Printf("[INFO] Setting PCC for LAN %s to %s...", d.Id(), newPCC.(string))
 			properties.PCC = newPCC.(string)
+		} else {
+			log.Printf("[INFO] Detaching LAN %s from PCC %s...", d.Id(), oldPCC.(string))
+			if err := lanDetachPCC(client, d.Get("datacenter_id").(string), d.Id()); err != nil {
+				return fmt.Errorf("An error occured while detaching LAN %s from PCC %s: %w", d.Id(), oldPCC.(string), err)
+			}
+
+			log.Printf("[INFO] Waiting for LAN %s to be available...", d.Id())
+			if _, err := lanStateChangeConf(client, d, schema.TimeoutUpdate).WaitForState(); err != nil {
+				return fmt.Errorf("Error while waiting for LAN %s to be detached from PCC %s: %w", d.Id(), oldPCC.(string), err)
+			}
+			log.Printf("[INFO] LAN %s detached from PCC %s", d.Id(), oldPCC.(string))
 		}
 	}
 
 	if properties != nil {
-		updatedLAN, err := client.UpdateLan(d.Get("datacenter_id").(string), d.Id(), *properties)
+		body, err := clearedProperties(properties, clearedName(d, "name")...)
 		if err != nil {
-			return fmt.Errorf("An error occured while patching a lan ID %s %s", d.Id(), err)
+			return err
+		}
+		updatedLAN := &profitbricks.Lan{}
+		err = client.Patch(fmt.Sprintf("/datacenters/%s/lans/%s", d.Get("datacenter_id").(string), d.Id()), body, updatedLAN, http.StatusAccepted)
+		if err != nil {
+			return fmt.Errorf("An error occured while patching a lan ID %s %w", d.Id(), err)
 		}
 
 		for {
 			log.Printf("[INFO] Waiting for LAN %s to be available...", d.Id())
-			time.Sleep(5 * time.Second)
+			time.Sleep(pollInterval(client, 5*time.Second))
 
 			clusterReady, rsErr := lanAvailable(client, d)
 
 			if rsErr != nil {
-				return fmt.Errorf("Error while checking readiness status of LAN %s: %s", d.Id(), rsErr)
+				return fmt.Errorf("Error while checking readiness status of LAN %s: %w", d.Id(), rsErr)
 			}
 
 			if clusterReady && rsErr == nil {
@@ -189,7 +215,7 @@ func resourceProfitBricksLanDelete(d *schema.ResourceData, meta interface{}) err
 		if err != nil {
 			if apiError, ok := err.(profitbricks.ApiError); ok {
 				if apiError.HttpStatusCode() != 404 {
-					return fmt.Errorf("An error occured while deleting a lan dcId %s ID %s %s", d.Get("datacenter_id").(string), d.Id(), err)
+					return fmt.Errorf("An error occured while deleting a lan dcId %s ID %s %w", d.Get("datacenter_id").(string), d.Id(), err)
 				}
 			}
 		}
@@ -197,12 +223,12 @@ func resourceProfitBricksLanDelete(d *schema.ResourceData, meta interface{}) err
 
 	for {
 		log.Printf("[INFO] Waiting for LAN %s to be deleted...", d.Id())
-		time.Sleep(5 * time.Second)
+		time.Sleep(pollInterval(client, 5*time.Second))
 
 		lDeleted, dsErr := lanDeleted(client, d)
 
 		if dsErr != nil {
-			return fmt.Errorf("Error while checking deletion status of LAN %s: %s", d.Id(), dsErr)
+			return fmt.Errorf("Error while checking deletion status of LAN %s: %w", d.Id(), dsErr)
 		}
 
 		if lDeleted && dsErr == nil {
@@ -221,11 +247,37 @@ func lanAvailable(client *profitbricks.Client, d *schema.ResourceData) (bool, er
 	log.Printf("[INFO] Current status for LAN %s: %+v", d.Id(), subjectLAN)
 
 	if err != nil {
-		return true, fmt.Errorf("Error checking LAN status: %s", err)
+		return true, fmt.Errorf("Error checking LAN status: %w", err)
 	}
 	return subjectLAN.Metadata.State == "AVAILABLE", nil
 }
 
+// lanStateChangeConf waits for a LAN to reach the AVAILABLE state
+func lanStateChangeConf(client *profitbricks.Client, d *schema.ResourceData, timeoutType string) *resource.StateChangeConf {
+	return &resource.StateChangeConf{
+		Pending:    []string{"BUSY", "DEPLOYING", "UPDATING"},
+		Target:     []string{"AVAILABLE"},
+		Refresh:    lanStateRefreshFunc(client, d.Get("datacenter_id").(string), d.Id()),
+		Timeout:    d.Timeout(timeoutType),
+		MinTimeout: pollInterval(client, 5*time.Second),
+		Delay:      pollInterval(client, 5*time.Second),
+	}
+}
+
+// lanStateRefreshFunc reports the metadata state of a LAN
+func lanStateRefreshFunc(client *profitbricks.Client, dcID string, lanID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		lan, err := client.GetLan(dcID, lanID)
+		if err != nil {
+			return nil, "", fmt.Errorf("Error checking LAN status: %w", err)
+		}
+		if lan.Metadata == nil {
+			return lan, "BUSY", nil
+		}
+		return lan, lan.Metadata.State, nil
+	}
+}
+
 func lanDeleted(client *profitbricks.Client, d *schema.ResourceData) (bool, error) {
 	subjectLAN, err := client.GetLan(d.Get("datacenter_id").(string), d.Id())
 
@@ -236,9 +288,17 @@ func lanDeleted(client *profitbricks.Client, d *schema.ResourceData) (bool, erro
 			if apiError.HttpStatusCode() == 404 {
 				return true, nil
 			}
-			return true, fmt.Errorf("Error checking LAN deletion status: %s", err)
+			return true, fmt.Errorf("Error checking LAN deletion status: %w", err)
 		}
 	}
 	log.Printf("[INFO] LAN %s not deleted yet deleted LAN: %+v", d.Id(), subjectLAN)
 	return false, nil
 }
+
+// lanDetachPCC removes a LAN from its private cross-connect. The pcc property has to be sent as null,
+// which can not be expressed through profitbricks.LanProperties
+func lanDetachPCC(client *profitbricks.Client, dcID string, lanID string) error {
+	path := fmt.Sprintf("/datacenters/%s/lans/%s", dcID, lanID)
+	body := map[string]interface{}{"pcc": nil}
+	return client.Patch(path, body, &profitbricks.Lan{}, http.StatusAccepted)
+}
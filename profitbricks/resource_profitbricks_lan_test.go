@@ -2,13 +2,51 @@ package profitbricks
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
 )
 
+func TestLanStateChangeConf(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != "/datacenters/dc/lans/1" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if calls < 3 {
+			w.Write([]byte(`{"id": "1", "metadata": {"state": "BUSY"}}`))
+			return
+		}
+		w.Write([]byte(`{"id": "1", "metadata": {"state": "AVAILABLE"}}`))
+	}))
+	defer server.Close()
+
+	config := Config{Token: "token", Endpoint: server.URL, PollInterval: 1}
+	client, err := config.Client("0.12")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceProfitBricksLan().Schema, map[string]interface{}{
+		"datacenter_id": "dc",
+	})
+	d.SetId("1")
+
+	if _, err := lanStateChangeConf(client, d, schema.TimeoutUpdate).WaitForState(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls)
+	}
+}
+
 func TestAccProfitBricksLan_Basic(t *testing.T) {
 	var lan profitbricks.Lan
 	lanName := "lanName"
@@ -39,6 +77,72 @@ func TestAccProfitBricksLan_Basic(t *testing.T) {
 	})
 }
 
+func TestAccProfitBricksLan_Rename(t *testing.T) {
+	var lanId string
+	resourceName := "profitbricks_lan.webserver_lan"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksLanDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckProfitbricksLanConfig_basic, "lan"),
+				Check: func(s *terraform.State) error {
+					lanId = s.RootModule().Resources[resourceName].Primary.ID
+					return nil
+				},
+			},
+			{
+				// a name only change updates the LAN in place
+				Config: fmt.Sprintf(testAccCheckProfitbricksLanConfig_basic, "renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(resourceName, "id", &lanId),
+					resource.TestCheckResourceAttr(resourceName, "name", "renamed"),
+				),
+			},
+			{
+				// the name is cleared as well
+				Config: fmt.Sprintf(testAccCheckProfitbricksLanConfig_basic, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(resourceName, "id", &lanId),
+					resource.TestCheckResourceAttr(resourceName, "name", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProfitBricksLan_PrivateCrossConnect(t *testing.T) {
+	var lan profitbricks.Lan
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksLanDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckProfitbricksLanConfig_pcc,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksLanExists("profitbricks_lan.pcc_lan", &lan),
+					resource.TestCheckResourceAttrPair("profitbricks_lan.pcc_lan", "pcc", "profitbricks_private_crossconnect.example", "id"),
+				),
+			},
+			{
+				Config: testAccCheckProfitbricksLanConfig_pccDetached,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksLanExists("profitbricks_lan.pcc_lan", &lan),
+					resource.TestCheckResourceAttr("profitbricks_lan.pcc_lan", "pcc", ""),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckDProfitBricksLanDestroyCheck(s *terraform.State) error {
 	client := testAccProvider.Meta().(*profitbricks.Client)
 	for _, rs := range s.RootModule().Resources {
@@ -124,3 +228,38 @@ resource "profitbricks_lan" "webserver_lan" {
   public = true
   name = "updated"
 }`
+
+const testAccCheckProfitbricksLanConfig_pcc = `
+resource "profitbricks_datacenter" "foobar" {
+	name       = "lan-pcc-test"
+	location = "us/las"
+}
+
+resource "profitbricks_private_crossconnect" "example" {
+  name        = "lan-pcc-test"
+  description = "lan-pcc-test"
+}
+
+resource "profitbricks_lan" "pcc_lan" {
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  public = false
+  name = "pcc_lan"
+  pcc = "${profitbricks_private_crossconnect.example.id}"
+}`
+
+const testAccCheckProfitbricksLanConfig_pccDetached = `
+resource "profitbricks_datacenter" "foobar" {
+	name       = "lan-pcc-test"
+	location = "us/las"
+}
+
+resource "profitbricks_private_crossconnect" "example" {
+  name        = "lan-pcc-test"
+  description = "lan-pcc-test"
+}
+
+resource "profitbricks_lan" "pcc_lan" {
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  public = false
+  name = "pcc_lan"
+}`
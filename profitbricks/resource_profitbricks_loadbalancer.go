@@ -66,12 +66,12 @@ func resourceProfitBricksLoadbalancerCreate(d *schema.ResourceData, meta interfa
 	lb, err := client.CreateLoadbalancer(d.Get("datacenter_id").(string), *lb)
 
 	if err != nil {
-		return fmt.Errorf("Error occured while creating a loadbalancer %s", err)
+		return fmt.Errorf("Error occured while creating a loadbalancer %w", err)
 	}
 	d.SetId(lb.ID)
 
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, lb.Headers.Get("Location"), schema.TimeoutCreate).WaitForState()
+	_, errState := waitForRequest(meta, d, lb.Headers.Get("Location"), schema.TimeoutCreate)
 	if errState != nil {
 		if IsRequestFailed(err) {
 			// Request failed, so resource was not created, delete resource from state file
@@ -94,7 +94,7 @@ func resourceProfitBricksLoadbalancerRead(d *schema.ResourceData, meta interface
 				return nil
 			}
 		}
-		return fmt.Errorf("An error occured while fetching a lan ID %s %s", d.Id(), err)
+		return fmt.Errorf("An error occured while fetching a lan ID %s %w", d.Id(), err)
 	}
 
 	d.Set("name", lb.Properties.Name)
@@ -129,11 +129,11 @@ func resourceProfitBricksLoadbalancerUpdate(d *schema.ResourceData, meta interfa
 
 			resp, err := client.DeleteBalancedNic(d.Get("datacenter_id").(string), d.Id(), o.(string))
 			if err != nil {
-				return fmt.Errorf("Error occured while deleting a balanced nic: %s", err)
+				return fmt.Errorf("Error occured while deleting a balanced nic: %w", err)
 			}
 
 			// Wait, catching any errors
-			_, errState := getStateChangeConf(meta, d, resp.Get("Location"), schema.TimeoutUpdate).WaitForState()
+			_, errState := waitForRequest(meta, d, resp.Get("Location"), schema.TimeoutUpdate)
 			if errState != nil {
 				return errState
 			}
@@ -144,11 +144,11 @@ func resourceProfitBricksLoadbalancerUpdate(d *schema.ResourceData, meta interfa
 		for _, o := range newList {
 			nic, err := client.AssociateNic(d.Get("datacenter_id").(string), d.Id(), o.(string))
 			if err != nil {
-				return fmt.Errorf("Error occured while deleting a balanced nic: %s", err)
+				return fmt.Errorf("Error occured while deleting a balanced nic: %w", err)
 			}
 
 			// Wait, catching any errors
-			_, errState := getStateChangeConf(meta, d, nic.Headers.Get("Location"), schema.TimeoutUpdate).WaitForState()
+			_, errState := waitForRequest(meta, d, nic.Headers.Get("Location"), schema.TimeoutUpdate)
 			if errState != nil {
 				return errState
 			}
@@ -165,11 +165,11 @@ func resourceProfitBricksLoadbalancerDelete(d *schema.ResourceData, meta interfa
 	resp, err := client.DeleteLoadbalancer(d.Get("datacenter_id").(string), d.Id())
 
 	if err != nil {
-		return fmt.Errorf("Error occured while deleting a loadbalancer: %s", err)
+		return fmt.Errorf("Error occured while deleting a loadbalancer: %w", err)
 	}
 
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, resp.Get("Location"), schema.TimeoutDelete).WaitForState()
+	_, errState := waitForRequest(meta, d, resp.Get("Location"), schema.TimeoutDelete)
 	if errState != nil {
 		return errState
 	}
@@ -18,6 +18,7 @@ func resourceProfitBricksNic() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceProfitBricksNicImport,
 		},
+		CustomizeDiff: resourceProfitBricksNicCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 
 			"lan": {
@@ -33,8 +34,10 @@ func resourceProfitBricksNic() *schema.Resource {
 				Optional: true,
 			},
 			"ip": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateIPv4Addresses,
+				Computed:     true,
 			},
 			"ips": {
 				Type:     schema.TypeList,
@@ -49,11 +52,41 @@ func resourceProfitBricksNic() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+			"mac": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"firewall_rules": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"protocol": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 			"server_id": {
 				Type:     schema.TypeString,
 				Required: true,
 				ForceNew: true,
 			},
+			"restart_server_on_attach": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Stop a running server whose boot device does not support nic hot plug to attach the nic, and start it again afterwards",
+			},
 			"datacenter_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -64,6 +97,29 @@ func resourceProfitBricksNic() *schema.Resource {
 	}
 }
 
+// errNicWithoutIps is returned for a nic with dhcp disabled and no ips, it would not get any address
+const errNicWithoutIps = "A nic with dhcp disabled needs at least one ip, set 'ip' or enable 'dhcp'"
+
+// resourceProfitBricksNicCustomizeDiff rejects a nic with dhcp disabled and no ips. The ip of a new nic is
+// only known when it is configured, so a missing one is caught by the create before calling the API
+func resourceProfitBricksNicCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	dhcp, dhcpOk := diff.GetOkExists("dhcp")
+	if !dhcpOk || !diff.NewValueKnown("ip") {
+		return nil
+	}
+	ip := diff.Get("ip").(string)
+
+	if !dhcp.(bool) && ip == "" {
+		return fmt.Errorf(errNicWithoutIps)
+	}
+
+	if dhcp.(bool) && ip != "" && diff.HasChange("ip") {
+		log.Printf("[WARN] The nic has dhcp enabled and static ips %s, dhcp may hand out other addresses", ip)
+	}
+
+	return nil
+}
+
 func resourceProfitBricksNicCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*profitbricks.Client)
 	nic := &profitbricks.Nic{
@@ -84,6 +140,9 @@ func resourceProfitBricksNicCreate(d *schema.ResourceData, meta interface{}) err
 		ips := strings.Split(raw, ",")
 		nic.Properties.Ips = ips
 	}
+	if nic.Properties.Dhcp != nil && !*nic.Properties.Dhcp && len(nic.Properties.Ips) == 0 {
+		return fmt.Errorf(errNicWithoutIps)
+	}
 	if _, ok := d.GetOk("firewall_active"); ok {
 		raw := d.Get("firewall_active").(bool)
 		nic.Properties.FirewallActive = &raw
@@ -93,19 +152,54 @@ func resourceProfitBricksNicCreate(d *schema.ResourceData, meta interface{}) err
 		nic.Properties.Nat = &raw
 	}
 
-	nic, err := client.CreateNic(d.Get("datacenter_id").(string), d.Get("server_id").(string), *nic)
+	dcId := d.Get("datacenter_id").(string)
+	serverId := d.Get("server_id").(string)
+
+	// a running server has to support nic hot plug to get the nic, otherwise it is stopped for attaching it if allowed
+	supported, err := serverSupportsNicHotPlug(client, dcId, serverId)
 	if err != nil {
-		return fmt.Errorf("Error occured while creating a nic: %s", err)
+		return err
+	}
+	stoppedForNic := false
+	if !supported {
+		if !d.Get("restart_server_on_attach").(bool) {
+			return fmt.Errorf("Server ID %s is running and its boot device does not support nic hot plug, so the nic cannot be attached. Set 'restart_server_on_attach' to stop the server for attaching the nic, or stop it by setting 'vm_state' to SHUTOFF", serverId)
+		}
+		log.Printf("[INFO] Stopping server %s to attach a nic", serverId)
+		if err := changeServerVmStateByID(d, meta, dcId, serverId, "SHUTOFF", schema.TimeoutCreate); err != nil {
+			return err
+		}
+		stoppedForNic = true
+	}
+
+	// failing after the server was stopped for the nic starts it again
+	failed := func(err error) error {
+		if stoppedForNic {
+			return startServerAfterFailure(d, meta, dcId, serverId, schema.TimeoutCreate, err)
+		}
+		return err
+	}
+
+	nic, err = client.CreateNic(dcId, serverId, *nic)
+	if err != nil {
+		return failed(fmt.Errorf("Error occured while creating a nic: %w", err))
 	}
 	d.SetId(nic.ID)
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, nic.Headers.Get("Location"), schema.TimeoutCreate).WaitForState()
+	_, errState := waitForRequest(meta, d, nic.Headers.Get("Location"), schema.TimeoutCreate)
 	if errState != nil {
 		if IsRequestFailed(err) {
 			// Request failed, so resource was not created, delete resource from state file
 			d.SetId("")
 		}
-		return errState
+		return failed(errState)
+	}
+
+	if stoppedForNic {
+		log.Printf("[INFO] Starting server %s again after attaching nic %s", serverId, d.Id())
+		if err := changeServerVmStateByID(d, meta, dcId, serverId, "RUNNING", schema.TimeoutCreate); err != nil {
+			return err
+		}
 	}
 	return resourceProfitBricksNicRead(d, meta)
 }
@@ -120,7 +214,7 @@ func resourceProfitBricksNicRead(d *schema.ResourceData, meta interface{}) error
 				return nil
 			}
 		}
-		return fmt.Errorf("Error occured while fetching a nic ID %s %s", d.Id(), err)
+		return fmt.Errorf("Error occured while fetching a nic ID %s %w", d.Id(), err)
 	}
 	if nic.Properties != nil {
 		log.Printf("[INFO] LAN ON NIC: %d", nic.Properties.Lan)
@@ -128,7 +222,27 @@ func resourceProfitBricksNicRead(d *schema.ResourceData, meta interface{}) error
 		d.Set("lan", nic.Properties.Lan)
 		d.Set("name", nic.Properties.Name)
 		d.Set("ips", nic.Properties.Ips)
+		d.Set("ip", strings.Join(nic.Properties.Ips, ","))
+		d.Set("mac", nic.Properties.Mac)
 		d.Set("firewall_active", nic.Properties.FirewallActive)
+		if nic.Properties.Nat != nil {
+			d.Set("nat", *nic.Properties.Nat)
+		}
+	}
+
+	// the rules are managed by profitbricks_firewall, they are only listed here
+	firewallRules := []map[string]interface{}{}
+	if nic.Entities != nil && nic.Entities.FirewallRules != nil {
+		for _, rule := range nic.Entities.FirewallRules.Items {
+			firewallRules = append(firewallRules, map[string]interface{}{
+				"id":       rule.ID,
+				"name":     rule.Properties.Name,
+				"protocol": rule.Properties.Protocol,
+			})
+		}
+	}
+	if err := d.Set("firewall_rules", firewallRules); err != nil {
+		return err
 	}
 
 	return nil
@@ -138,6 +252,11 @@ func resourceProfitBricksNicUpdate(d *schema.ResourceData, meta interface{}) err
 	client := meta.(*profitbricks.Client)
 	properties := profitbricks.NicProperties{}
 
+	// restart_server_on_attach only matters to the provider
+	if !d.HasChanges("name", "lan", "dhcp", "ip", "nat") {
+		return resourceProfitBricksNicRead(d, meta)
+	}
+
 	if d.HasChange("name") {
 		_, n := d.GetChange("name")
 
@@ -164,11 +283,11 @@ func resourceProfitBricksNicUpdate(d *schema.ResourceData, meta interface{}) err
 	nic, err := client.UpdateNic(d.Get("datacenter_id").(string), d.Get("server_id").(string), d.Id(), properties)
 
 	if err != nil {
-		return fmt.Errorf("Error occured while updating a nic: %s", err)
+		return fmt.Errorf("Error occured while updating a nic: %w", err)
 	}
 
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, nic.Headers.Get("Location"), schema.TimeoutUpdate).WaitForState()
+	_, errState := waitForRequest(meta, d, nic.Headers.Get("Location"), schema.TimeoutUpdate)
 	if errState != nil {
 		return errState
 	}
@@ -181,10 +300,10 @@ func resourceProfitBricksNicDelete(d *schema.ResourceData, meta interface{}) err
 	resp, err := client.DeleteNic(d.Get("datacenter_id").(string), d.Get("server_id").(string), d.Id())
 
 	if err != nil {
-		return fmt.Errorf("An error occured while deleting a nic dcId %s ID %s %s", d.Get("datacenter_id").(string), d.Id(), err)
+		return fmt.Errorf("An error occured while deleting a nic dcId %s ID %s %w", d.Get("datacenter_id").(string), d.Id(), err)
 	}
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, resp.Get("Location"), schema.TimeoutDelete).WaitForState()
+	_, errState := waitForRequest(meta, d, resp.Get("Location"), schema.TimeoutDelete)
 	if errState != nil {
 		return errState
 	}
@@ -192,3 +311,34 @@ func resourceProfitBricksNicDelete(d *schema.ResourceData, meta interface{}) err
 	d.SetId("")
 	return nil
 }
+
+// serverSupportsNicHotPlug reports whether a nic can be attached to the server as it is, which a running server
+// only allows when its boot volume, or the image of its boot cdrom, supports nic hot plug
+func serverSupportsNicHotPlug(client *profitbricks.Client, dcId string, serverId string) (bool, error) {
+	server, err := client.GetServer(dcId, serverId)
+	if err != nil {
+		return false, fmt.Errorf("Error occured while fetching server ID %s %w", serverId, err)
+	}
+
+	if server.Properties.VMState != "RUNNING" {
+		return true, nil
+	}
+
+	if server.Properties.BootVolume != nil {
+		volume, err := client.GetVolume(dcId, server.Properties.BootVolume.ID)
+		if err != nil {
+			return false, fmt.Errorf("Error occured while fetching the boot volume of server ID %s %w", serverId, err)
+		}
+		return volume.Properties.NicHotPlug, nil
+	}
+	if server.Properties.BootCdrom != nil {
+		image, err := client.GetImage(server.Properties.BootCdrom.ID)
+		if err != nil {
+			return false, fmt.Errorf("Error occured while fetching the boot cdrom of server ID %s %w", serverId, err)
+		}
+		return image.Properties.NicHotPlug, nil
+	}
+
+	// without a boot device there is nothing to tell, the API decides then
+	return true, nil
+}
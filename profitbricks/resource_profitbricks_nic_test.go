@@ -2,13 +2,92 @@ package profitbricks
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
 )
 
+func TestServerSupportsNicHotPlug(t *testing.T) {
+	for _, tc := range []struct {
+		server    string
+		supported bool
+	}{
+		{`{"id": "server", "properties": {"vmState": "SHUTOFF", "bootVolume": {"id": "plain"}}}`, true},
+		{`{"id": "server", "properties": {"vmState": "RUNNING", "bootVolume": {"id": "plain"}}}`, false},
+		{`{"id": "server", "properties": {"vmState": "RUNNING", "bootVolume": {"id": "hotplug"}}}`, true},
+		{`{"id": "server", "properties": {"vmState": "RUNNING", "bootCdrom": {"id": "image"}}}`, true},
+		{`{"id": "server", "properties": {"vmState": "RUNNING"}}`, true},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/datacenters/dc/servers/server":
+				w.Write([]byte(tc.server))
+			case "/datacenters/dc/volumes/plain":
+				w.Write([]byte(`{"id": "plain", "properties": {"nicHotPlug": false}}`))
+			case "/datacenters/dc/volumes/hotplug":
+				w.Write([]byte(`{"id": "hotplug", "properties": {"nicHotPlug": true}}`))
+			case "/images/image":
+				w.Write([]byte(`{"id": "image", "properties": {"nicHotPlug": true}}`))
+			default:
+				t.Errorf("unexpected request %s", r.URL.Path)
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+
+		config := Config{Token: "token", Endpoint: server.URL}
+		client, err := config.Client("0.12")
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		supported, err := serverSupportsNicHotPlug(client, "dc", "server")
+		server.Close()
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if supported != tc.supported {
+			t.Errorf("expected %v for %s, got %v", tc.supported, tc.server, supported)
+		}
+	}
+}
+
+func TestStartServerAfterFailure(t *testing.T) {
+	started := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/datacenters/dc/servers/server/start" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		started = true
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"httpStatus": 422, "messages": [{"errorCode": "100", "message": "cannot start"}]}`))
+	}))
+	defer server.Close()
+
+	config := Config{Token: "token", Endpoint: server.URL, PollInterval: 1}
+	client, err := config.Client("0.12")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceProfitBricksNic().Schema, map[string]interface{}{})
+	err = startServerAfterFailure(d, client, "dc", "server", schema.TimeoutCreate, fmt.Errorf("creating the nic failed"))
+	if !started {
+		t.Fatalf("expected the server to be started")
+	}
+	if err == nil || !strings.HasPrefix(err.Error(), "creating the nic failed") || !strings.Contains(err.Error(), "Starting the stopped server server again failed") {
+		t.Fatalf("expected the original error and the failed start to be reported, got %v", err)
+	}
+}
+
 func TestAccProfitBricksNic_Basic(t *testing.T) {
 	var nic profitbricks.Nic
 	volumeName := "volume"
@@ -39,6 +118,21 @@ func TestAccProfitBricksNic_Basic(t *testing.T) {
 	})
 }
 
+func TestAccProfitBricksNic_Validation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckProfitbricksNicConfig_validation,
+				ExpectError: regexp.MustCompile(`A nic with dhcp disabled needs at least one ip`),
+			},
+		},
+	})
+}
+
 func testAccCheckDProfitBricksNicDestroyCheck(s *terraform.State) error {
 	client := testAccProvider.Meta().(*profitbricks.Client)
 	for _, rs := range s.RootModule().Resources {
@@ -102,6 +196,14 @@ func testAccCheckProfitBricksNICExists(n string, nic *profitbricks.Nic) resource
 	}
 }
 
+const testAccCheckProfitbricksNicConfig_validation = `
+resource "profitbricks_nic" "invalid" {
+  datacenter_id = "datacenterId"
+  server_id = "serverId"
+  lan = 2
+  dhcp = false
+}`
+
 const testAccCheckProfitbricksNicConfig_basic = `
 resource "profitbricks_datacenter" "foobar" {
 	name       = "nic-test"
@@ -134,7 +236,7 @@ resource "profitbricks_nic" "database_nic" {
   datacenter_id = "${profitbricks_datacenter.foobar.id}"
   server_id = "${profitbricks_server.webserver.id}"
   lan = 2
-  dhcp = false
+  dhcp = true
   firewall_active = true
   name = "%s"
 }`
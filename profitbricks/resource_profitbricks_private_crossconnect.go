@@ -5,6 +5,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
 )
@@ -29,64 +30,8 @@ func resourcePrivateCrossConnect() *schema.Resource {
 				Description: "The desired description for the private cross-connect",
 				Optional:    true,
 			},
-			"connectable_datacenters": {
-				Type:        schema.TypeList,
-				Description: "A list containing all the connectable datacenters",
-				Computed:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"id": {
-							Type:        schema.TypeString,
-							Description: "The UUID of the connectable datacenter",
-							Computed:    true,
-						},
-						"name": {
-							Type:        schema.TypeString,
-							Description: "The name of the connectable datacenter",
-							Computed:    true,
-						},
-						"location": {
-							Type:        schema.TypeString,
-							Description: "The physical location of the connectable datacenter",
-							Computed:    true,
-						},
-					},
-				},
-			},
-			"peers": {
-				Type:        schema.TypeList,
-				Description: "A list containing the details of all datacenter cross-connected through this private cross-connect",
-				Computed:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"lan_id": {
-							Type:        schema.TypeString,
-							Description: "The id of the cross-connected LAN",
-							Computed:    true,
-						},
-						"lan_name": {
-							Type:        schema.TypeString,
-							Description: "The name of the cross-connected LAN",
-							Computed:    true,
-						},
-						"datacenter_id": {
-							Type:        schema.TypeString,
-							Description: "The id of the cross-connected datacenter",
-							Computed:    true,
-						},
-						"datacenter_name": {
-							Type:        schema.TypeString,
-							Description: "The name of the cross-connected datacenter",
-							Computed:    true,
-						},
-						"location": {
-							Type:        schema.TypeString,
-							Description: "The location of the cross-connected datacenter",
-							Computed:    true,
-						},
-					},
-				},
-			},
+			"connectable_datacenters": privateCrossConnectConnectableDatacentersSchema(),
+			"peers":                   privateCrossConnectPeersSchema(),
 		},
 		Timeouts: &resourceDefaultTimeouts,
 	}
@@ -110,7 +55,7 @@ func resourcePrivateCrossConnectCreate(d *schema.ResourceData, meta interface{})
 
 	if err != nil {
 		d.SetId("")
-		return fmt.Errorf("Error creating private PCC: %s", err)
+		return fmt.Errorf("Error creating private PCC: %w", err)
 	}
 
 	d.SetId(createdPCC.ID)
@@ -118,12 +63,12 @@ func resourcePrivateCrossConnectCreate(d *schema.ResourceData, meta interface{})
 
 	for {
 		log.Printf("[INFO] Waiting for PCC %s to be ready...", d.Id())
-		time.Sleep(5 * time.Second)
+		time.Sleep(pollInterval(client, 5*time.Second))
 
 		pccReady, rsErr := privateCrossConnectReady(client, d)
 
 		if rsErr != nil {
-			return fmt.Errorf("Error while checking readiness status of PCC %s: %s", d.Id(), rsErr)
+			return fmt.Errorf("Error while checking readiness status of PCC %s: %w", d.Id(), rsErr)
 		}
 
 		if pccReady && rsErr == nil {
@@ -147,37 +92,22 @@ func resourcePrivateCrossConnectRead(d *schema.ResourceData, meta interface{}) e
 				return nil
 			}
 		}
-		return fmt.Errorf("Error while fetching PCC %s: %s", d.Id(), err)
+		return fmt.Errorf("Error while fetching PCC %s: %w", d.Id(), err)
 	}
 
 	log.Printf("[INFO] Successfully retreived PCC %s: %+v", d.Id(), pcc)
 
-	peers := []map[string]string{}
-
-	for _, peer := range *pcc.Properties.Peers {
-		peers = append(peers, map[string]string{
-			"lan_id":          peer.LANId,
-			"lan_name":        peer.LANName,
-			"datacenter_id":   peer.DataCenterID,
-			"datacenter_name": peer.DataCenterName,
-			"location":        peer.Location,
-		})
+	if pcc.Properties == nil {
+		return nil
 	}
 
-	d.Set("peers", peers)
-	log.Printf("[INFO] Setting peers for PCC %s to %+v...", d.Id(), d.Get("peers"))
-
-	connectableDatacenters := []map[string]string{}
+	d.Set("name", pcc.Properties.Name)
+	d.Set("description", pcc.Properties.Description)
 
-	for _, connectableDC := range *pcc.Properties.ConnectableDatacenters {
-		connectableDatacenters = append(connectableDatacenters, map[string]string{
-			"id":       connectableDC.ID,
-			"name":     connectableDC.Name,
-			"location": connectableDC.Location,
-		})
-	}
+	d.Set("peers", flattenPrivateCrossConnectPeers(pcc.Properties))
+	log.Printf("[INFO] Setting peers for PCC %s to %+v...", d.Id(), d.Get("peers"))
 
-	d.Set("connectable_datacenters", connectableDatacenters)
+	d.Set("connectable_datacenters", flattenPrivateCrossConnectConnectableDatacenters(pcc.Properties))
 
 	return nil
 }
@@ -214,19 +144,19 @@ func resourcePrivateCrossConnectUpdate(d *schema.ResourceData, meta interface{})
 				d.SetId("")
 				return nil
 			}
-			return fmt.Errorf("Error while updating PCC: %s", err)
+			return fmt.Errorf("Error while updating PCC: %w", err)
 		}
-		return fmt.Errorf("Error while updating PCC %s: %s", d.Id(), err)
+		return fmt.Errorf("Error while updating PCC %s: %w", d.Id(), err)
 	}
 
 	for {
 		log.Printf("[INFO] Waiting for PCC %s to be ready...", d.Id())
-		time.Sleep(5 * time.Second)
+		time.Sleep(pollInterval(client, 5*time.Second))
 
 		pccReady, rsErr := privateCrossConnectReady(client, d)
 
 		if rsErr != nil {
-			return fmt.Errorf("Error while checking readiness status of PCC %s: %s", d.Id(), rsErr)
+			return fmt.Errorf("Error while checking readiness status of PCC %s: %w", d.Id(), rsErr)
 		}
 
 		if pccReady && rsErr == nil {
@@ -241,7 +171,7 @@ func resourcePrivateCrossConnectUpdate(d *schema.ResourceData, meta interface{})
 func resourcePrivateCrossConnectDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*profitbricks.Client)
 
-	_, err := client.DeletePrivateCrossConnect(d.Id())
+	resp, err := client.DeletePrivateCrossConnect(d.Id())
 
 	if err != nil {
 		if apiError, ok := err.(profitbricks.ApiError); ok {
@@ -249,28 +179,34 @@ func resourcePrivateCrossConnectDelete(d *schema.ResourceData, meta interface{})
 				d.SetId("")
 				return nil
 			}
-			return fmt.Errorf("Error while deleting PCC: %s", err)
+			return fmt.Errorf("Error while deleting PCC %s: %w", d.Id(), apiError)
 		}
 
-		return fmt.Errorf("Error while deleting PCC %s: %s", d.Id(), err)
+		return fmt.Errorf("Error while deleting PCC %s: %w", d.Id(), err)
 	}
 
-	for {
-		log.Printf("[INFO] Waiting for PCC %s to be deleted...", d.Id())
-		time.Sleep(5 * time.Second)
-
-		pccDeleted, dsErr := privateCrossConnectDeleted(client, d)
-
-		if dsErr != nil {
-			return fmt.Errorf("Error while checking deletion status of PCC %s: %s", d.Id(), dsErr)
+	// a PCC which still has member LANs is rejected asynchronously, so the request status has to be checked
+	if location := resp.Get("Location"); location != "" {
+		if _, errState := waitForRequest(meta, d, location, schema.TimeoutDelete); errState != nil {
+			return fmt.Errorf("Error while deleting PCC %s, make sure no LANs are connected to it anymore: %w", d.Id(), errState)
 		}
+	}
 
-		if pccDeleted && dsErr == nil {
-			log.Printf("[INFO] Successfully deleted PCC: %s", d.Id())
-			break
-		}
+	log.Printf("[INFO] Waiting for PCC %s to be deleted...", d.Id())
+	deleteConf := &resource.StateChangeConf{
+		Pending:    []string{"AVAILABLE", "BUSY"},
+		Target:     []string{"DELETED"},
+		Refresh:    privateCrossConnectDeletedRefreshFunc(client, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		MinTimeout: pollInterval(client, 5*time.Second),
+		Delay:      pollInterval(client, 5*time.Second),
 	}
 
+	if _, err := deleteConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error while waiting for PCC %s to be deleted: %w", d.Id(), err)
+	}
+	log.Printf("[INFO] Successfully deleted PCC: %s", d.Id())
+
 	return nil
 }
 
@@ -278,21 +214,124 @@ func privateCrossConnectReady(client *profitbricks.Client, d *schema.ResourceDat
 	subjectPCC, err := client.GetPrivateCrossConnect(d.Id())
 
 	if err != nil {
-		return true, fmt.Errorf("Error checking PCC status: %s", err)
+		return true, fmt.Errorf("Error checking PCC status: %w", err)
 	}
 	return subjectPCC.Metadata.State == "AVAILABLE", nil
 }
 
-func privateCrossConnectDeleted(client *profitbricks.Client, d *schema.ResourceData) (bool, error) {
-	_, err := client.GetPrivateCrossConnect(d.Id())
-
-	if err != nil {
-		if apiError, ok := err.(profitbricks.ApiError); ok {
-			if apiError.HttpStatusCode() == 404 {
-				return true, nil
+// privateCrossConnectDeletedRefreshFunc reports DELETED once a PCC can no longer be found
+func privateCrossConnectDeletedRefreshFunc(client *profitbricks.Client, pccID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		pcc, err := client.GetPrivateCrossConnect(pccID)
+		if err != nil {
+			if apiError, ok := err.(profitbricks.ApiError); ok && apiError.HttpStatusCode() == 404 {
+				return pccID, "DELETED", nil
 			}
-			return true, fmt.Errorf("Error checking PCC deletion status: %s", err)
+			return nil, "", fmt.Errorf("Error checking PCC deletion status: %w", err)
+		}
+		if pcc.Metadata == nil {
+			return pcc, "BUSY", nil
+		}
+		return pcc, pcc.Metadata.State, nil
+	}
+}
+
+// privateCrossConnectConnectableDatacentersSchema describes the datacenters which can be connected to a private cross-connect
+func privateCrossConnectConnectableDatacentersSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "A list containing all the connectable datacenters",
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:        schema.TypeString,
+					Description: "The UUID of the connectable datacenter",
+					Computed:    true,
+				},
+				"name": {
+					Type:        schema.TypeString,
+					Description: "The name of the connectable datacenter",
+					Computed:    true,
+				},
+				"location": {
+					Type:        schema.TypeString,
+					Description: "The physical location of the connectable datacenter",
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+// privateCrossConnectPeersSchema describes the LANs connected through a private cross-connect
+func privateCrossConnectPeersSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "A list containing the details of all datacenter cross-connected through this private cross-connect",
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"lan_id": {
+					Type:        schema.TypeString,
+					Description: "The id of the cross-connected LAN",
+					Computed:    true,
+				},
+				"lan_name": {
+					Type:        schema.TypeString,
+					Description: "The name of the cross-connected LAN",
+					Computed:    true,
+				},
+				"datacenter_id": {
+					Type:        schema.TypeString,
+					Description: "The id of the cross-connected datacenter",
+					Computed:    true,
+				},
+				"datacenter_name": {
+					Type:        schema.TypeString,
+					Description: "The name of the cross-connected datacenter",
+					Computed:    true,
+				},
+				"location": {
+					Type:        schema.TypeString,
+					Description: "The location of the cross-connected datacenter",
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+func flattenPrivateCrossConnectPeers(properties *profitbricks.PrivateCrossConnectProperties) []map[string]string {
+	peers := []map[string]string{}
+
+	if properties.Peers != nil {
+		for _, peer := range *properties.Peers {
+			peers = append(peers, map[string]string{
+				"lan_id":          peer.LANId,
+				"lan_name":        peer.LANName,
+				"datacenter_id":   peer.DataCenterID,
+				"datacenter_name": peer.DataCenterName,
+				"location":        peer.Location,
+			})
+		}
+	}
+
+	return peers
+}
+
+func flattenPrivateCrossConnectConnectableDatacenters(properties *profitbricks.PrivateCrossConnectProperties) []map[string]string {
+	connectableDatacenters := []map[string]string{}
+
+	if properties.ConnectableDatacenters != nil {
+		for _, connectableDC := range *properties.ConnectableDatacenters {
+			connectableDatacenters = append(connectableDatacenters, map[string]string{
+				"id":       connectableDC.ID,
+				"name":     connectableDC.Name,
+				"location": connectableDC.Location,
+			})
 		}
 	}
-	return false, nil
+
+	return connectableDatacenters
 }
@@ -23,16 +23,19 @@ func resourceS3Key() *schema.Resource {
 				Type:        schema.TypeString,
 				Description: "The ID of the user that owns the key.",
 				Required:    true,
+				ForceNew:    true,
 			},
 			"secret_key": {
 				Type:        schema.TypeString,
 				Description: "The S3 Secret key.",
 				Computed:    true,
+				Sensitive:   true,
 			},
 			"active": {
 				Type:        schema.TypeBool,
 				Description: "Whether this key should be active or not.",
 				Optional:    true,
+				Default:     true,
 			},
 		},
 		Timeouts: &resourceDefaultTimeouts,
@@ -46,12 +49,22 @@ func resourceS3KeyCreate(d *schema.ResourceData, meta interface{}) error {
 
 	if err != nil {
 		d.SetId("")
-		return fmt.Errorf("Error creating S3 key: %s", err)
+		return fmt.Errorf("Error creating S3 key: %w", err)
 	}
 
 	d.SetId(createdS3Key.ID)
 	log.Printf("[INFO] Created S3 key: %s", d.Id())
 
+	if createdS3Key.Properties != nil {
+		d.Set("secret_key", createdS3Key.Properties.SecretKey)
+	}
+
+	// keys are always created active
+	if !d.Get("active").(bool) {
+		log.Printf("[INFO] Deactivating newly created S3 key %s", d.Id())
+		return resourceS3KeyUpdate(d, meta)
+	}
+
 	return resourceS3KeyRead(d, meta)
 }
 
@@ -74,7 +87,11 @@ func resourceS3KeyRead(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[INFO] Successfully retreived S3 key %s: %+v", d.Id(), s3Key)
 
 	d.SetId(s3Key.ID)
-	d.Set("secret_key", s3Key.Properties.SecretKey)
+	if s3Key.Properties.SecretKey != "" {
+		d.Set("secret_key", s3Key.Properties.SecretKey)
+	} else {
+		log.Printf("[INFO] The API did not return the secret of S3 key %s, keeping the one known from create", d.Id())
+	}
 	d.Set("active", s3Key.Properties.Active)
 
 	return nil
@@ -88,10 +105,10 @@ func resourceS3KeyUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	log.Printf("[INFO] Attempting to update S3 key %s", d.Id())
 
+	request.Properties.Active = d.Get("active").(bool)
 	if d.HasChange("active") {
 		oldActiveSetting, newActiveSetting := d.GetChange("active")
 		log.Printf("[INFO] S3 key active setting changed from %+v to %+v", oldActiveSetting, newActiveSetting)
-		request.Properties.Active = newActiveSetting.(bool)
 	}
 
 	updatedS3Key, err := client.UpdateS3Key(d.Get("user_id").(string), d.Id(), request)
@@ -104,19 +121,19 @@ func resourceS3KeyUpdate(d *schema.ResourceData, meta interface{}) error {
 				d.SetId("")
 				return nil
 			}
-			return fmt.Errorf("Error while updating S3 key: %s", err)
+			return fmt.Errorf("Error while updating S3 key: %w", err)
 		}
-		return fmt.Errorf("Error while updating S3 key %s: %s", d.Id(), err)
+		return fmt.Errorf("Error while updating S3 key %s: %w", d.Id(), err)
 	}
 
 	for {
 		log.Printf("[INFO] Waiting for S3 Key %s to be ready...", d.Id())
-		time.Sleep(5 * time.Second)
+		time.Sleep(pollInterval(client, 5*time.Second))
 
 		s3KeyReady, rsErr := s3Ready(client, d, updatedS3Key)
 
 		if rsErr != nil {
-			return fmt.Errorf("Error while checking readiness status of S3 Key %s: %s", d.Id(), rsErr)
+			return fmt.Errorf("Error while checking readiness status of S3 Key %s: %w", d.Id(), rsErr)
 		}
 
 		if s3KeyReady && rsErr == nil {
@@ -139,20 +156,20 @@ func resourceS3KeyDelete(d *schema.ResourceData, meta interface{}) error {
 				d.SetId("")
 				return nil
 			}
-			return fmt.Errorf("Error while deleting S3 key: %s", err)
+			return fmt.Errorf("Error while deleting S3 key: %w", err)
 		}
 
-		return fmt.Errorf("Error while deleting S3 key %s: %s", d.Id(), err)
+		return fmt.Errorf("Error while deleting S3 key %s: %w", d.Id(), err)
 	}
 
 	for {
 		log.Printf("[INFO] Waiting for s3Key %s to be deleted...", d.Id())
-		time.Sleep(5 * time.Second)
+		time.Sleep(pollInterval(client, 5*time.Second))
 
 		s3KeyDeleted, dsErr := s3KeyDeleted(client, d)
 
 		if dsErr != nil {
-			return fmt.Errorf("Error while checking deletion status of S3 key %s: %s", d.Id(), dsErr)
+			return fmt.Errorf("Error while checking deletion status of S3 key %s: %w", d.Id(), dsErr)
 		}
 
 		if s3KeyDeleted && dsErr == nil {
@@ -172,7 +189,7 @@ func s3KeyDeleted(client *profitbricks.Client, d *schema.ResourceData) (bool, er
 			if apiError.HttpStatusCode() == 404 {
 				return true, nil
 			}
-			return true, fmt.Errorf("Error checking S3 key deletion status: %s", err)
+			return true, fmt.Errorf("Error checking S3 key deletion status: %w", err)
 		}
 	}
 	return false, nil
@@ -182,7 +199,7 @@ func s3Ready(client *profitbricks.Client, d *schema.ResourceData, s3Key *profitb
 	subjectS3Key, err := client.GetS3Key(d.Get("user_id").(string), d.Id())
 
 	if err != nil {
-		return true, fmt.Errorf("Error checking S3 Key status: %s", err)
+		return true, fmt.Errorf("Error checking S3 Key status: %w", err)
 	}
 	return subjectS3Key.Properties.Active == d.Get("active").(bool), nil
 }
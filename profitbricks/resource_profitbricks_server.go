@@ -5,9 +5,13 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"net/http"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
 	"golang.org/x/crypto/ssh"
@@ -22,6 +26,7 @@ func resourceProfitBricksServer() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceProfitBricksServerImport,
 		},
+		CustomizeDiff: resourceProfitBricksServerCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			// Server parameters
 			"name": {
@@ -39,6 +44,15 @@ func resourceProfitBricksServer() *schema.Resource {
 			"availability_zone": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Default:  "AUTO",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					switch v.(string) {
+					case "AUTO", "ZONE_1", "ZONE_2":
+					default:
+						errors = append(errors, fmt.Errorf("%q must be one of AUTO, ZONE_1 or ZONE_2, got %q", k, v.(string)))
+					}
+					return
+				},
 			},
 			"licence_type": {
 				Type:     schema.TypeString,
@@ -46,13 +60,87 @@ func resourceProfitBricksServer() *schema.Resource {
 			},
 
 			"boot_volume": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:        schema.TypeString,
+				Description: "The id of the attached volume the server boots from",
+				Optional:    true,
+				Computed:    true,
+			},
+			"inline_volume_id": {
+				Type:        schema.TypeString,
+				Description: "The id of the volume created with the server from the volume block",
+				Computed:    true,
+			},
+
+			"attached_volumes": {
+				Type:        schema.TypeList,
+				Description: "The volumes attached to the server, ordered by their device number. Read-only, the boot volume is chosen with boot_volume",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"device_number": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"boot": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
 			},
 
 			"boot_cdrom": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:          schema.TypeString,
+				Description:   "The id of the CD-ROM image the server boots from",
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"boot_volume"},
+			},
+			"boot_from_network": {
+				Type:          schema.TypeBool,
+				Description:   "Clears the boot device, so the server boots from the network",
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"boot_volume", "boot_cdrom"},
+			},
+			"reboot_on_change": {
+				Type:        schema.TypeMap,
+				Description: "Arbitrary values which reboot the server whenever they change",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"reboot_on_resize": {
+				Type:        schema.TypeBool,
+				Description: "Whether to reboot the server when cores or ram change and its boot volume does not support hot plugging them",
+				Optional:    true,
+				Default:     false,
+			},
+			"vm_state": {
+				Type:        schema.TypeString,
+				Description: "The power state of the server, either RUNNING or SHUTOFF",
+				Optional:    true,
+				Default:     "RUNNING",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if v.(string) != "RUNNING" && v.(string) != "SHUTOFF" {
+						errors = append(errors, fmt.Errorf("%q must be either RUNNING or SHUTOFF, got %q", k, v.(string)))
+					}
+					return
+				},
+			},
+			"cdroms": {
+				Type:        schema.TypeSet,
+				Description: "The ids of the CD-ROM images attached to the server",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Optional:    true,
 			},
 			"cpu_family": {
 				Type:     schema.TypeString,
@@ -72,6 +160,12 @@ func resourceProfitBricksServer() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"primary_ips": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "All IPs of the primary nic, the first one is the primary_ip",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 			"firewallrule_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -191,9 +285,12 @@ func resourceProfitBricksServer() *schema.Resource {
 			"nic": {
 				Type:     schema.TypeList,
 				Required: true,
-				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
 						"lan": {
 							Type:     schema.TypeInt,
 							Required: true,
@@ -208,8 +305,9 @@ func resourceProfitBricksServer() *schema.Resource {
 						},
 
 						"ip": {
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateIPv4Addresses,
 							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 								if new == "" {
 									return true
@@ -236,6 +334,10 @@ func resourceProfitBricksServer() *schema.Resource {
 							MaxItems: 1,
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
 									"name": {
 										Type:     schema.TypeString,
 										Optional: true,
@@ -256,12 +358,14 @@ func resourceProfitBricksServer() *schema.Resource {
 										Optional: true,
 									},
 									"source_ip": {
-										Type:     schema.TypeString,
-										Optional: true,
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validateIPv4Address,
 									},
 									"target_ip": {
-										Type:     schema.TypeString,
-										Optional: true,
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validateIPv4Address,
 									},
 									"ip": {
 										Type:     schema.TypeString,
@@ -308,6 +412,7 @@ func resourceProfitBricksServer() *schema.Resource {
 					},
 				},
 			},
+			"labels": labelsSchema(),
 		},
 		Timeouts: &resourceDefaultTimeouts,
 	}
@@ -405,7 +510,7 @@ func resourceProfitBricksServerCreate(d *schema.ResourceData, meta interface{})
 			} else {
 				dc, err := client.GetDatacenter(dcId)
 				if err != nil {
-					return fmt.Errorf("Error fetching datacenter %s: (%s)", dcId, err)
+					return fmt.Errorf("Error fetching datacenter %s: (%w)", dcId, err)
 				}
 				image_alias = getImageAlias(client, image_name, dc.Properties.Location)
 			}
@@ -439,7 +544,7 @@ func resourceProfitBricksServerCreate(d *schema.ResourceData, meta interface{})
 
 		} else {
 			if err != nil {
-				return fmt.Errorf("Error fetching image/snapshot: %s", err)
+				return fmt.Errorf("Error fetching image/snapshot: %w", err)
 			}
 		}
 
@@ -501,6 +606,12 @@ func resourceProfitBricksServerCreate(d *schema.ResourceData, meta interface{})
 		return fmt.Errorf("Passwords/SSH keys are not supported for snapshots.")
 	}
 
+	if isSnapshot && image != "" {
+		if err := waitForSnapshotAvailable(d, meta, image, schema.TimeoutCreate); err != nil {
+			return err
+		}
+	}
+
 	volume.ImageAlias = image_alias
 	volume.Image = image
 
@@ -514,97 +625,11 @@ func resourceProfitBricksServerCreate(d *schema.ResourceData, meta interface{})
 		},
 	}
 
-	if _, ok := d.GetOk("nic"); ok {
-		nic := profitbricks.Nic{Properties: &profitbricks.NicProperties{
-			Lan: d.Get("nic.0.lan").(int),
-		}}
-
-		if v, ok := d.GetOk("nic.0.name"); ok {
-			nic.Properties.Name = v.(string)
-		}
-
-		nic.Properties.Dhcp = boolAddr(d.Get("nic.0.dhcp").(bool))
-		nic.Properties.FirewallActive = boolAddr(d.Get("nic.0.firewall_active").(bool))
-		nic.Properties.Nat = boolAddr(d.Get("nic.0.nat").(bool))
-
-		if v, ok := d.GetOk("nic.0.ip"); ok {
-			ips := strings.Split(v.(string), ",")
-			if len(ips) > 0 {
-				nic.Properties.Ips = ips
-			}
-		}
-
-		log.Printf("[DEBUG] dhcp nic before%t", *nic.Properties.Dhcp)
-		request.Entities.Nics = &profitbricks.Nics{
-			Items: []profitbricks.Nic{
-				nic,
-			},
-		}
-		log.Printf("[DEBUG] dhcp nic after %t", *nic.Properties.Dhcp)
-		log.Printf("[DEBUG] dhcp %t", *request.Entities.Nics.Items[0].Properties.Dhcp)
-
-		if _, ok := d.GetOk("nic.0.firewall"); ok {
-			firewall := profitbricks.FirewallRule{
-				Properties: profitbricks.FirewallruleProperties{
-					Protocol: d.Get("nic.0.firewall.0.protocol").(string),
-				},
-			}
-
-			if v, ok := d.GetOk("nic.0.firewall.0.name"); ok {
-				firewall.Properties.Name = v.(string)
-			}
-
-			if v, ok := d.GetOk("nic.0.firewall.0.source_mac"); ok {
-				val := v.(string)
-				firewall.Properties.SourceMac = &val
-			}
-
-			if v, ok := d.GetOk("nic.0.firewall.0.source_ip"); ok {
-				val := v.(string)
-				firewall.Properties.SourceIP = &val
-			}
-
-			if v, ok := d.GetOk("nic.0.firewall.0.target_ip"); ok {
-				val := v.(string)
-				firewall.Properties.TargetIP = &val
-			}
-
-			if v, ok := d.GetOk("nic.0.firewall.0.port_range_start"); ok {
-				val := v.(int)
-				firewall.Properties.PortRangeStart = &val
-			}
-
-			if v, ok := d.GetOk("nic.0.firewall.0.port_range_end"); ok {
-				val := v.(int)
-				firewall.Properties.PortRangeEnd = &val
-			}
-
-			if v, ok := d.GetOk("nic.0.firewall.0.icmp_type"); ok {
-				tempIcmpType := v.(string)
-				if tempIcmpType != "" {
-					i, _ := strconv.Atoi(tempIcmpType)
-					firewall.Properties.IcmpType = &i
-				}
-			}
-			if v, ok := d.GetOk("nic.0.firewall.0.icmp_code"); ok {
-				tempIcmpCode := v.(string)
-				if tempIcmpCode != "" {
-					i, _ := strconv.Atoi(tempIcmpCode)
-					firewall.Properties.IcmpCode = &i
-				}
-			}
-			request.Entities.Nics.Items[0].Entities = &profitbricks.NicEntities{
-				FirewallRules: &profitbricks.FirewallRules{
-					Items: []profitbricks.FirewallRule{
-						firewall,
-					},
-				},
-			}
-		}
-	}
-
-	if len(request.Entities.Nics.Items[0].Properties.Ips) == 0 {
-		request.Entities.Nics.Items[0].Properties.Ips = nil
+	nics := d.Get("nic").([]interface{})
+	request.Entities.Nics = &profitbricks.Nics{
+		Items: []profitbricks.Nic{
+			getServerNic(d, "nic.0"),
+		},
 	}
 
 	server, err := client.CreateServer(d.Get("datacenter_id").(string), request)
@@ -616,7 +641,7 @@ func resourceProfitBricksServerCreate(d *schema.ResourceData, meta interface{})
 	d.SetId(server.ID)
 
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, server.Headers.Get("Location"), schema.TimeoutCreate).WaitForState()
+	_, errState := waitForRequest(meta, d, server.Headers.Get("Location"), schema.TimeoutCreate)
 	if errState != nil {
 		if IsRequestFailed(err) {
 			// Request failed, so resource was not created, delete resource from state file
@@ -626,16 +651,38 @@ func resourceProfitBricksServerCreate(d *schema.ResourceData, meta interface{})
 	}
 	server, err = client.GetServer(d.Get("datacenter_id").(string), server.ID)
 	if err != nil {
-		return fmt.Errorf("Error fetching server: (%s)", err)
+		return fmt.Errorf("Error fetching server: (%w)", err)
+	}
+
+	// the volume block is the only volume the server is created with
+	if server.Entities != nil && server.Entities.Volumes != nil && len(server.Entities.Volumes.Items) > 0 {
+		d.Set("inline_volume_id", server.Entities.Volumes.Items[0].ID)
 	}
 
 	firewallRules, err := client.ListFirewallRules(d.Get("datacenter_id").(string), server.ID, server.Entities.Nics.Items[0].ID)
+	if err != nil {
+		return fmt.Errorf("Error fetching firewall rules of nic %s: (%w)", server.Entities.Nics.Items[0].ID, err)
+	}
 
+	nicIds := []string{server.Entities.Nics.Items[0].ID}
+	firewallIds := []string{""}
 	if len(firewallRules.Items) > 0 {
 		d.Set("firewallrule_id", firewallRules.Items[0].ID)
+		firewallIds[0] = firewallRules.Items[0].ID
 	}
 
 	d.Set("primary_nic", server.Entities.Nics.Items[0].ID)
+
+	// additional nics are created one after the other, so they keep the order of the configuration
+	for i := 1; i < len(nics); i++ {
+		nicId, firewallId, err := createServerNic(d, meta, fmt.Sprintf("nic.%d", i), schema.TimeoutCreate)
+		if err != nil {
+			return err
+		}
+		nicIds = append(nicIds, nicId)
+		firewallIds = append(firewallIds, firewallId)
+	}
+	setServerNicIds(d, nicIds, firewallIds)
 	if len(server.Entities.Nics.Items[0].Properties.Ips) > 0 {
 		d.SetConnInfo(map[string]string{
 			"type":     "ssh",
@@ -643,6 +690,32 @@ func resourceProfitBricksServerCreate(d *schema.ResourceData, meta interface{})
 			"password": request.Entities.Volumes.Items[0].Properties.ImagePassword,
 		})
 	}
+
+	if v, ok := d.GetOk("cdroms"); ok {
+		if err := attachServerCdroms(d, meta, v.(*schema.Set).List(), schema.TimeoutCreate); err != nil {
+			return err
+		}
+	}
+
+	// the server is created booting from its volume
+	_, bootCdromOk := d.GetOk("boot_cdrom")
+	if bootCdromOk || d.Get("boot_from_network").(bool) {
+		if err := updateServerBootDevice(d, meta, schema.TimeoutCreate); err != nil {
+			return err
+		}
+	}
+
+	// servers always come up running, so they only have to be stopped when asked to
+	if d.Get("vm_state").(string) == "SHUTOFF" {
+		if err := updateServerVmState(d, meta, schema.TimeoutCreate); err != nil {
+			return err
+		}
+	}
+
+	if err := updateLabels(client, d, serverLabelsPath(dcId, d.Id())); err != nil {
+		return err
+	}
+
 	return resourceProfitBricksServerRead(d, meta)
 }
 
@@ -714,28 +787,52 @@ func resourceProfitBricksServerRead(d *schema.ResourceData, meta interface{}) er
 				return nil
 			}
 		}
-		return fmt.Errorf("Error occured while fetching a server ID %s %s", d.Id(), err)
+		return fmt.Errorf("Error occured while fetching a server ID %s %w", d.Id(), err)
 	}
 	d.Set("name", server.Properties.Name)
 	d.Set("cores", server.Properties.Cores)
 	d.Set("ram", server.Properties.RAM)
 	d.Set("availability_zone", server.Properties.AvailabilityZone)
 	d.Set("cpu_family", server.Properties.CPUFamily)
-	d.Set("boot_image", server.Entities.Volumes.Items[0].Properties.Image)
+	d.Set("vm_state", server.Properties.VMState)
+	if server.Entities != nil && server.Entities.Volumes != nil && len(server.Entities.Volumes.Items) > 0 {
+		d.Set("boot_image", server.Entities.Volumes.Items[0].Properties.Image)
+	}
+
+	nics := d.Get("nic").([]interface{})
+	if len(nics) == 0 && d.Get("primary_nic").(string) != "" {
+		// imported servers only know their primary nic
+		nics = []interface{}{map[string]interface{}{}}
+	}
 
-	if primarynic, ok := d.GetOk("primary_nic"); ok {
-		d.Set("primary_nic", primarynic.(string))
+	networks := []map[string]interface{}{}
+	for i, raw := range nics {
+		nicId, firewallId := serverNicIds(d, raw, i)
+		if nicId == "" {
+			continue
+		}
 
-		nic, err := client.GetNic(dcId, serverId, primarynic.(string))
+		nic, err := client.GetNic(dcId, serverId, nicId)
 		if err != nil {
-			return fmt.Errorf("Error occured while fetching nic %s for server ID %s %s", primarynic.(string), d.Id(), err)
+			if apiError, ok := err.(profitbricks.ApiError); ok && apiError.HttpStatusCode() == 404 {
+				log.Printf("[INFO] Nic %s of server ID %s is gone", nicId, serverId)
+				continue
+			}
+			return fmt.Errorf("Error occured while fetching nic %s for server ID %s %w", nicId, d.Id(), err)
 		}
 
-		if len(nic.Properties.Ips) > 0 {
-			d.Set("primary_ip", nic.Properties.Ips[0])
+		if i == 0 {
+			d.Set("primary_nic", nic.ID)
+			d.Set("primary_ips", nic.Properties.Ips)
+			if len(nic.Properties.Ips) > 0 {
+				d.Set("primary_ip", nic.Properties.Ips[0])
+			} else {
+				d.Set("primary_ip", "")
+			}
 		}
 
 		network := map[string]interface{}{
+			"id":              nic.ID,
 			"lan":             nic.Properties.Lan,
 			"name":            nic.Properties.Name,
 			"dhcp":            *nic.Properties.Dhcp,
@@ -748,13 +845,14 @@ func resourceProfitBricksServerRead(d *schema.ResourceData, meta interface{}) er
 			network["ip"] = nic.Properties.Ips[0]
 		}
 
-		if firewall_id, ok := d.GetOk("firewallrule_id"); ok {
-			firewall, err := client.GetFirewallRule(dcId, serverId, primarynic.(string), firewall_id.(string))
+		if firewallId != "" {
+			firewall, err := client.GetFirewallRule(dcId, serverId, nicId, firewallId)
 			if err != nil {
-				return fmt.Errorf("Error occured while fetching firewallrule %s for server ID %s %s", firewall_id.(string), serverId, err)
+				return fmt.Errorf("Error occured while fetching firewallrule %s for server ID %s %w", firewallId, serverId, err)
 			}
 
 			fw := map[string]interface{}{
+				"id":       firewall.ID,
 				"protocol": firewall.Properties.Protocol,
 				"name":     firewall.Properties.Name,
 			}
@@ -790,16 +888,38 @@ func resourceProfitBricksServerRead(d *schema.ResourceData, meta interface{}) er
 			network["firewall"] = []map[string]interface{}{fw}
 		}
 
-		networks := []map[string]interface{}{network}
+		networks = append(networks, network)
+	}
+
+	if len(networks) > 0 {
 		if err := d.Set("nic", networks); err != nil {
-			return fmt.Errorf("[ERROR] unable saving nic to state ProfitBricks Server (%s): %s", serverId, err)
+			return fmt.Errorf("[ERROR] unable saving nic to state ProfitBricks Server (%s): %w", serverId, err)
 		}
 	}
 
 	if server.Properties.BootVolume != nil {
 		d.Set("boot_volume", server.Properties.BootVolume.ID)
-		volumeObj, err := client.GetAttachedVolume(dcId, serverId, server.Properties.BootVolume.ID)
-		if err == nil {
+	} else {
+		d.Set("boot_volume", "")
+	}
+
+	// servers created before the volume block was tracked by its id, and imported ones, booted from it
+	inlineVolumeId := d.Get("inline_volume_id").(string)
+	if inlineVolumeId == "" && server.Properties.BootVolume != nil {
+		inlineVolumeId = server.Properties.BootVolume.ID
+		d.Set("inline_volume_id", inlineVolumeId)
+	}
+
+	// the volume block is read from the volume created with the server, whichever device the server boots from
+	if inlineVolumeId != "" {
+		volumeObj, err := client.GetAttachedVolume(dcId, serverId, inlineVolumeId)
+		if err != nil {
+			if apiError, ok := err.(profitbricks.ApiError); !ok || apiError.HttpStatusCode() != 404 {
+				return fmt.Errorf("Error occured while fetching volume %s of server ID %s %w", inlineVolumeId, serverId, err)
+			}
+			log.Printf("[INFO] Volume %s is no longer attached to server ID %s", inlineVolumeId, serverId)
+			d.Set("volume", nil)
+		} else {
 			volumeItem := map[string]interface{}{
 				"name":              volumeObj.Properties.Name,
 				"disk_type":         volumeObj.Properties.Type,
@@ -811,22 +931,218 @@ func resourceProfitBricksServerRead(d *schema.ResourceData, meta interface{}) er
 
 			volumesList := []map[string]interface{}{volumeItem}
 			if err := d.Set("volume", volumesList); err != nil {
-				return fmt.Errorf("[DEBUG] Error saving volume to state for ProfitBricks server (%s): %s", d.Id(), err)
+				return fmt.Errorf("[DEBUG] Error saving volume to state for ProfitBricks server (%s): %w", d.Id(), err)
 			}
 		}
 	}
 
-	_, err = client.GetAttachedVolume(dcId, d.Id(), d.Get("boot_volume").(string))
+	if server.Properties.BootCdrom != nil {
+		d.Set("boot_cdrom", server.Properties.BootCdrom.ID)
+	} else {
+		d.Set("boot_cdrom", "")
+	}
+
+	d.Set("boot_from_network", server.Properties.BootVolume == nil && server.Properties.BootCdrom == nil)
+
+	cdroms, err := client.ListAttachedCdroms(dcId, serverId)
 	if err != nil {
-		d.Set("volume", nil)
+		return fmt.Errorf("Error occured while fetching the CD-ROMs of server ID %s %w", serverId, err)
 	}
 
-	if server.Properties.BootCdrom != nil {
-		d.Set("boot_cdrom", server.Properties.BootCdrom.ID)
+	cdromIds := []string{}
+	for _, cdrom := range cdroms.Items {
+		cdromIds = append(cdromIds, cdrom.ID)
+	}
+	d.Set("cdroms", cdromIds)
+
+	volumes, err := client.ListAttachedVolumes(dcId, serverId)
+	if err != nil {
+		return fmt.Errorf("Error occured while fetching the volumes of server ID %s %w", serverId, err)
+	}
+
+	sort.Slice(volumes.Items, func(i, j int) bool {
+		return volumes.Items[i].Properties.DeviceNumber < volumes.Items[j].Properties.DeviceNumber
+	})
+
+	attachedVolumes := []map[string]interface{}{}
+	for _, volume := range volumes.Items {
+		attachedVolumes = append(attachedVolumes, map[string]interface{}{
+			"id":            volume.ID,
+			"name":          volume.Properties.Name,
+			"device_number": int(volume.Properties.DeviceNumber),
+			"boot":          server.Properties.BootVolume != nil && server.Properties.BootVolume.ID == volume.ID,
+		})
+	}
+	if err := d.Set("attached_volumes", attachedVolumes); err != nil {
+		return fmt.Errorf("Error while setting attached_volumes of server ID %s: %w", serverId, err)
+	}
+
+	if err := readLabels(client, d, serverLabelsPath(dcId, serverId)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// defaultCPUFamily is the cpu family the API gives servers created without one, when the location offers it
+const defaultCPUFamily = "AMD_OPTERON"
+
+// resourceProfitBricksServerCustomizeDiff previews the cpu family of a new server and rejects a cpu family
+// which is not offered in the location of the data center. With check_capacity the location and contract
+// are checked for new servers as well
+func resourceProfitBricksServerCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+
+	if diff.Id() == "" {
+		if err := resourceProfitBricksServerPreviewCPUFamily(diff, meta); err != nil {
+			return err
+		}
+	}
+
+	if checkCapacity(client) {
+		return resourceProfitBricksServerCheckCapacity(diff, meta)
+	}
+
+	if diff.Id() == "" || !diff.HasChange("cpu_family") {
+		return nil
+	}
+
+	cpuFamily := diff.Get("cpu_family").(string)
+	if cpuFamily == "" {
+		return nil
+	}
+
+	_, err := serverCPUArchitecture(client, diff.Get("datacenter_id").(string), cpuFamily)
+	return err
+}
+
+// serverCPUArchitecture returns the cpu architecture of cpuFamily in the location of the data center, or an error
+// when the location does not offer it. Locations not listing their architectures return nil, the API decides then
+func serverCPUArchitecture(client *profitbricks.Client, dcId string, cpuFamily string) (*locationCPUArchitecture, error) {
+	dc, err := client.GetDatacenter(dcId)
+	if err != nil {
+		return nil, fmt.Errorf("An error occured while fetching a Datacenter ID %s %w", dcId, err)
+	}
+
+	location, err := getLocationWithExtras(client, dc.Properties.Location)
+	if err != nil {
+		return nil, fmt.Errorf("An error occured while fetching ProfitBricks location %s %w", dc.Properties.Location, err)
+	}
+
+	families := []string{}
+	for _, cpuArchitecture := range location.Properties.CPUArchitecture {
+		if cpuArchitecture.CPUFamily == cpuFamily {
+			return &cpuArchitecture, nil
+		}
+		families = append(families, cpuArchitecture.CPUFamily)
+	}
+
+	// an unknown list of families is not a reason to refuse the change, the API decides then
+	if len(families) == 0 {
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("cpu_family %q is not offered in location %s of datacenter %s, neither in place nor by replacing the server. Available cpu families: %s", cpuFamily, dc.Properties.Location, dcId, strings.Join(families, ", "))
+}
+
+// resourceProfitBricksServerCheckCapacity rejects a new or resized server whose cpu family, cores or ram the
+// location does not offer, or which needs more cores or ram than the contract has left
+func resourceProfitBricksServerCheckCapacity(diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() != "" && !diff.HasChange("cpu_family") && !diff.HasChange("cores") && !diff.HasChange("ram") {
+		return nil
+	}
+	for _, attr := range []string{"datacenter_id", "cores", "ram"} {
+		if !diff.NewValueKnown(attr) {
+			log.Printf("[INFO] Not checking the capacity for server %s, %s is not known yet", diff.Id(), attr)
+			return nil
+		}
+	}
+
+	client := meta.(*profitbricks.Client)
+	dcId := diff.Get("datacenter_id").(string)
+	cores := diff.Get("cores").(int)
+	ram := diff.Get("ram").(int)
+
+	if cpuFamily := diff.Get("cpu_family").(string); cpuFamily != "" && diff.NewValueKnown("cpu_family") {
+		cpuArchitecture, err := serverCPUArchitecture(client, dcId, cpuFamily)
+		if err != nil {
+			return err
+		}
+		if cpuArchitecture != nil && cpuArchitecture.MaxCores > 0 && cores > cpuArchitecture.MaxCores {
+			return fmt.Errorf("%d cores exceed the %d cores offered for cpu family %s in the location of datacenter %s", cores, cpuArchitecture.MaxCores, cpuFamily, dcId)
+		}
+		if cpuArchitecture != nil && cpuArchitecture.MaxRAM > 0 && ram > cpuArchitecture.MaxRAM {
+			return fmt.Errorf("%d MB of ram exceed the %d MB offered for cpu family %s in the location of datacenter %s", ram, cpuArchitecture.MaxRAM, cpuFamily, dcId)
+		}
+	}
+
+	contract, err := client.GetContractResources()
+	if err != nil {
+		return fmt.Errorf("An error occured while fetching the contract resources to check the capacity %w", err)
+	}
+	limits := contract.Properties.ResourceLimits
+	if limits == nil {
+		return nil
+	}
+
+	// a resized server only needs the difference to its current size
+	oldCores, _ := diff.GetChange("cores")
+	oldRam, _ := diff.GetChange("ram")
+	moreCores := cores - oldCores.(int)
+	moreRam := ram - oldRam.(int)
+
+	if limits.CoresPerServer > 0 && cores > int(limits.CoresPerServer) {
+		return fmt.Errorf("%d cores exceed the %d cores per server of the contract", cores, limits.CoresPerServer)
+	}
+	if limits.RAMPerServer > 0 && ram > int(limits.RAMPerServer) {
+		return fmt.Errorf("%d MB of ram exceed the %d MB per server of the contract", ram, limits.RAMPerServer)
+	}
+	if limits.CoresPerContract > 0 && moreCores > 0 && int(limits.CoresProvisioned)+moreCores > int(limits.CoresPerContract) {
+		return fmt.Errorf("The server needs %d more cores, the contract has %d of %d cores left", moreCores, limits.CoresPerContract-limits.CoresProvisioned, limits.CoresPerContract)
+	}
+	if limits.RAMPerContract > 0 && moreRam > 0 && int(limits.RAMProvisioned)+moreRam > int(limits.RAMPerContract) {
+		return fmt.Errorf("The server needs %d MB more ram, the contract has %d of %d MB left", moreRam, limits.RAMPerContract-limits.RAMProvisioned, limits.RAMPerContract)
 	}
+
 	return nil
 }
 
+// resourceProfitBricksServerPreviewCPUFamily shows the cpu family a new server without one will get in the plan,
+// when the location of its data center makes it certain. It only reads, the family is left to the API when
+// creating the server
+func resourceProfitBricksServerPreviewCPUFamily(diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.NewValueKnown("cpu_family") || !diff.NewValueKnown("datacenter_id") {
+		return nil
+	}
+
+	client := meta.(*profitbricks.Client)
+	dcId := diff.Get("datacenter_id").(string)
+	dc, err := client.GetDatacenter(dcId)
+	if err != nil {
+		return fmt.Errorf("An error occured while fetching a Datacenter ID %s %w", dcId, err)
+	}
+
+	location, err := getLocationWithExtras(client, dc.Properties.Location)
+	if err != nil {
+		return fmt.Errorf("An error occured while fetching ProfitBricks location %s %w", dc.Properties.Location, err)
+	}
+
+	cpuFamily := ""
+	for _, cpuArchitecture := range location.Properties.CPUArchitecture {
+		if cpuArchitecture.CPUFamily == defaultCPUFamily || len(location.Properties.CPUArchitecture) == 1 {
+			cpuFamily = cpuArchitecture.CPUFamily
+		}
+	}
+
+	if cpuFamily == "" {
+		log.Printf("[INFO] The cpu family of the new server is chosen by the API, location %s offers several", dc.Properties.Location)
+		return nil
+	}
+
+	log.Printf("[INFO] The new server gets cpu family %s in location %s", cpuFamily, dc.Properties.Location)
+	return diff.SetNew("cpu_family", cpuFamily)
+}
+
 func boolAddr(b bool) *bool {
 	return &b
 }
@@ -857,30 +1173,98 @@ func resourceProfitBricksServerUpdate(d *schema.ResourceData, meta interface{})
 		_, n := d.GetChange("cpu_family")
 		request.CPUFamily = n.(string)
 	}
+
+	// the cpu family of a running server cannot change, so it is stopped for the change and started again afterwards
+	stoppedForCpuFamily := false
+	if d.HasChange("cpu_family") {
+		current, err := client.GetServer(dcId, d.Id())
+		if err != nil {
+			return fmt.Errorf("Error occured while fetching server ID %s %w", d.Id(), err)
+		}
+		if current.Properties.VMState == "RUNNING" {
+			log.Printf("[INFO] Stopping server %s to change its cpu family to %s", d.Id(), request.CPUFamily)
+			if err := changeServerVmState(d, meta, "SHUTOFF", schema.TimeoutUpdate); err != nil {
+				return err
+			}
+			stoppedForCpuFamily = true
+		}
+	}
+	// failing after the server was stopped for the cpu family change starts it again
+	failed := func(err error) error {
+		if stoppedForCpuFamily {
+			return startServerAfterFailure(d, meta, dcId, d.Id(), schema.TimeoutUpdate, err)
+		}
+		return err
+	}
+
+	// a server to be stopped is stopped before the resize, so it does not need hot plug capabilities for it
+	stoppedForVmState := false
+	if d.HasChanges("cores", "ram") && d.HasChange("vm_state") && d.Get("vm_state").(string) == "SHUTOFF" && !stoppedForCpuFamily {
+		if err := updateServerVmState(d, meta, schema.TimeoutUpdate); err != nil {
+			return err
+		}
+		stoppedForVmState = true
+	}
+
+	rebootAfterResize := false
+	if d.HasChanges("cores", "ram") {
+		missing, err := serverMissingHotPlugCapabilities(d, meta)
+		if err != nil {
+			return failed(err)
+		}
+		if len(missing) > 0 {
+			if !d.Get("reboot_on_resize").(bool) {
+				return failed(fmt.Errorf("Server ID %s is running and its boot volume does not support %s, so the new cores and ram only take effect after a reboot. Set 'reboot_on_resize' to reboot the server or stop it by setting 'vm_state' to SHUTOFF", d.Id(), strings.Join(missing, ", ")))
+			}
+			rebootAfterResize = true
+		}
+	}
+
 	server, err := client.UpdateServer(dcId, d.Id(), request)
 
 	if err != nil {
-		return fmt.Errorf("Error occured while updating server ID %s %s", d.Id(), err)
+		return failed(fmt.Errorf("Error occured while updating server ID %s %w", d.Id(), err))
 	}
 
-	_, errState := getStateChangeConf(meta, d, server.Headers.Get("Location"), schema.TimeoutUpdate).WaitForState()
+	_, errState := waitForRequest(meta, d, server.Headers.Get("Location"), schema.TimeoutUpdate)
 	if errState != nil {
-		return errState
+		return failed(errState)
+	}
+
+	if d.HasChanges("cores", "ram") {
+		if err := waitForServerResources(d, meta, schema.TimeoutUpdate); err != nil {
+			return failed(err)
+		}
+		if rebootAfterResize {
+			if err := rebootServer(d, meta, schema.TimeoutUpdate); err != nil {
+				return failed(err)
+			}
+		}
+	}
+
+	if stoppedForCpuFamily && d.Get("vm_state").(string) == "RUNNING" {
+		log.Printf("[INFO] Starting server %s again after changing its cpu family", d.Id())
+		if err := changeServerVmState(d, meta, "RUNNING", schema.TimeoutUpdate); err != nil {
+			return err
+		}
 	}
-	// Volume stuff
+	// Volume stuff, the volume block is the volume created with the server, which need not be the boot volume
 	if d.HasChange("volume") {
-		boot_volume := d.Get("boot_volume").(string)
-		_, err = client.GetAttachedVolume(dcId, d.Id(), boot_volume)
+		inlineVolumeId := d.Get("inline_volume_id").(string)
+		if inlineVolumeId == "" {
+			return fmt.Errorf("Server ID %s does not know the volume it was created with, so the volume block cannot be updated", d.Id())
+		}
+		_, err = client.GetAttachedVolume(dcId, d.Id(), inlineVolumeId)
 
 		if err != nil {
 
-			volumeAttach, err := client.AttachVolume(dcId, d.Id(), boot_volume)
+			volumeAttach, err := client.AttachVolume(dcId, d.Id(), inlineVolumeId)
 			if err != nil {
-				return fmt.Errorf("An error occured while attaching a volume dcId: %s server_id: %s ID: %s Response: %s", dcId, d.Id(), boot_volume, err)
+				return fmt.Errorf("An error occured while attaching a volume dcId: %s server_id: %s ID: %s Response: %w", dcId, d.Id(), inlineVolumeId, err)
 			}
 
 			// Wait, catching any errors
-			_, errState = getStateChangeConf(meta, d, volumeAttach.Headers.Get("Location"), schema.TimeoutCreate).WaitForState()
+			_, errState = waitForRequest(meta, d, volumeAttach.Headers.Get("Location"), schema.TimeoutCreate)
 			if errState != nil {
 				return errState
 			}
@@ -900,117 +1284,591 @@ func resourceProfitBricksServerUpdate(d *schema.ResourceData, meta interface{})
 			properties.Bus = v.(string)
 		}
 
-		volume, err := client.UpdateVolume(d.Get("datacenter_id").(string), boot_volume, properties)
+		body, err := clearedProperties(properties, clearedName(d, "volume.0.name")...)
+		if err != nil {
+			return err
+		}
+
+		volume, err := updateVolumeWithExtras(client, d.Get("datacenter_id").(string), inlineVolumeId, body)
 
 		if err != nil {
-			return fmt.Errorf("Error patching volume (%s) (%s)", d.Id(), err)
+			return fmt.Errorf("Error patching volume (%s) (%w)", d.Id(), err)
 		}
 
 		// Wait, catching any errors
-		_, errState := getStateChangeConf(meta, d, volume.Headers.Get("Location"), schema.TimeoutUpdate).WaitForState()
+		_, errState := waitForRequest(meta, d, volume.Headers.Get("Location"), schema.TimeoutUpdate)
 		if errState != nil {
 			return errState
 		}
 	}
 
+	// new CD-ROMs are attached before the boot device changes, so the server can boot from one of them,
+	// and removed ones are detached afterwards, so the server no longer boots from them
+	var oldCdroms, newCdroms interface{}
+	if d.HasChange("cdroms") {
+		oldCdroms, newCdroms = d.GetChange("cdroms")
+
+		if err := attachServerCdroms(d, meta, newCdroms.(*schema.Set).Difference(oldCdroms.(*schema.Set)).List(), schema.TimeoutUpdate); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChanges("boot_volume", "boot_cdrom", "boot_from_network") {
+		if err := updateServerBootDevice(d, meta, schema.TimeoutUpdate); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("cdroms") {
+		for _, cdromId := range oldCdroms.(*schema.Set).Difference(newCdroms.(*schema.Set)).List() {
+			if cdromId.(string) == d.Get("boot_cdrom").(string) {
+				return fmt.Errorf("CD-ROM %s cannot be detached from server ID %s because the server boots from it, change the boot device first", cdromId, d.Id())
+			}
+
+			resp, err := client.DetachCdrom(dcId, d.Id(), cdromId.(string))
+			if err != nil {
+				return fmt.Errorf("An error occured while detaching CD-ROM %s from server ID %s %w", cdromId, d.Id(), err)
+			}
+
+			// Wait, catching any errors
+			_, errState := waitForRequest(meta, d, resp.Get("Location"), schema.TimeoutUpdate)
+			if errState != nil {
+				return errState
+			}
+		}
+	}
+
 	// Nic stuff
 	if d.HasChange("nic") {
-		nic := &profitbricks.Nic{}
-		for _, n := range server.Entities.Nics.Items {
-			if n.ID == d.Get("primary_nic").(string) {
-				nic = &n
-				break
+		oldNics, newNics := d.GetChange("nic")
+		nicIds := []string{}
+		firewallIds := []string{}
+
+		for i := range newNics.([]interface{}) {
+			path := fmt.Sprintf("nic.%d", i)
+
+			nicId, firewallId := "", ""
+			if i < len(oldNics.([]interface{})) {
+				nicId, firewallId = serverNicIds(d, oldNics.([]interface{})[i], i)
+			}
+
+			if nicId == "" {
+				nicId, firewallId, err = createServerNic(d, meta, path, schema.TimeoutUpdate)
+			} else if d.HasChange(path) {
+				firewallId, err = updateServerNic(d, meta, path, nicId, firewallId)
+			}
+			if err != nil {
+				return err
 			}
+
+			nicIds = append(nicIds, nicId)
+			firewallIds = append(firewallIds, firewallId)
 		}
 
-		properties := profitbricks.NicProperties{
-			Lan: d.Get("nic.0.lan").(int),
+		for i := len(newNics.([]interface{})); i < len(oldNics.([]interface{})); i++ {
+			nicId, _ := serverNicIds(d, oldNics.([]interface{})[i], i)
+			if nicId == "" {
+				continue
+			}
+
+			resp, err := client.DeleteNic(dcId, d.Id(), nicId)
+			if err != nil {
+				return fmt.Errorf("Error deleting nic %s of server ID %s (%w)", nicId, d.Id(), err)
+			}
+
+			// Wait, catching any errors
+			_, errState := waitForRequest(meta, d, resp.Get("Location"), schema.TimeoutUpdate)
+			if errState != nil {
+				return errState
+			}
 		}
 
-		if v, ok := d.GetOk("nic.0.name"); ok {
-			properties.Name = v.(string)
+		setServerNicIds(d, nicIds, firewallIds)
+	}
+
+	if stoppedForCpuFamily {
+		log.Printf("[INFO] Server %s was already stopped and started for the cpu family change, it is %s", d.Id(), d.Get("vm_state").(string))
+	} else if stoppedForVmState {
+		log.Printf("[INFO] Server %s was already stopped before changing its cores and ram", d.Id())
+	} else if d.HasChange("vm_state") {
+		if err := updateServerVmState(d, meta, schema.TimeoutUpdate); err != nil {
+			return err
+		}
+	} else if d.HasChange("reboot_on_change") {
+		// a stopped server is not rebooted, it would be started instead
+		if d.Get("vm_state").(string) == "RUNNING" {
+			if err := rebootServer(d, meta, schema.TimeoutUpdate); err != nil {
+				return err
+			}
+		} else {
+			log.Printf("[INFO] Not rebooting server %s, its vm_state is %s", d.Id(), d.Get("vm_state").(string))
 		}
+	}
+
+	if err := updateLabels(client, d, serverLabelsPath(dcId, d.Id())); err != nil {
+		return err
+	}
+
+	return resourceProfitBricksServerRead(d, meta)
+}
+
+func resourceProfitBricksServerDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*profitbricks.Client)
+	dcId := d.Get("datacenter_id").(string)
 
-		if v, ok := d.GetOk("nic.0.ip"); ok {
-			ips := strings.Split(v.(string), ",")
-			if len(ips) > 0 {
-				nic.Properties.Ips = ips
+	// only the volume created with the server is deleted, a volume the server boots from otherwise is managed on its own
+	if inlineVolumeId := d.Get("inline_volume_id").(string); inlineVolumeId != "" {
+		resp, err := client.DeleteVolume(dcId, inlineVolumeId)
+		if err != nil {
+			if apiError, ok := err.(profitbricks.ApiError); !ok || apiError.HttpStatusCode() != 404 {
+				return fmt.Errorf("Error occured while delete volume %s of server ID %s %w", inlineVolumeId, d.Id(), err)
+			}
+			log.Printf("[INFO] Volume %s of server ID %s is already gone", inlineVolumeId, d.Id())
+		} else {
+			// Wait, catching any errors
+			_, errState := waitForRequest(meta, d, resp.Get("Location"), schema.TimeoutDelete)
+			if errState != nil {
+				return errState
 			}
 		}
+	}
 
-		properties.Dhcp = boolAddr(d.Get("nic.0.dhcp").(bool))
+	resp, err := client.DeleteServer(dcId, d.Id())
+	if err != nil {
+		return fmt.Errorf("An error occured while deleting a server ID %s %w", d.Id(), err)
 
-		properties.Nat = boolAddr(d.Get("nic.0.nat").(bool))
-		properties.FirewallActive = boolAddr(d.Get("nic.0.firewall_active").(bool))
+	}
 
-		if d.HasChange("nic.0.firewall") {
+	// Wait, catching any errors
+	_, errState := waitForRequest(meta, d, resp.Get("Location"), schema.TimeoutDelete)
+	if errState != nil {
+		return errState
+	}
 
-			firewall := GetFirewallResource(d, "nic.0.firewall")
-			nic.Entities = &profitbricks.NicEntities{
-				FirewallRules: &profitbricks.FirewallRules{
-					Items: []profitbricks.FirewallRule{
-						firewall,
-					},
+	d.SetId("")
+	return nil
+}
+
+// getServerNic builds a nic, including its firewall rule, from the nic block at path
+func getServerNic(d *schema.ResourceData, path string) profitbricks.Nic {
+	nic := profitbricks.Nic{Properties: &profitbricks.NicProperties{
+		Lan: d.Get(path + ".lan").(int),
+	}}
+
+	if v, ok := d.GetOk(path + ".name"); ok {
+		nic.Properties.Name = v.(string)
+	}
+
+	nic.Properties.Dhcp = boolAddr(d.Get(path + ".dhcp").(bool))
+	nic.Properties.FirewallActive = boolAddr(d.Get(path + ".firewall_active").(bool))
+	nic.Properties.Nat = boolAddr(d.Get(path + ".nat").(bool))
+
+	if v, ok := d.GetOk(path + ".ip"); ok {
+		ips := strings.Split(v.(string), ",")
+		if len(ips) > 0 {
+			nic.Properties.Ips = ips
+		}
+	}
+
+	if _, ok := d.GetOk(path + ".firewall"); ok {
+		nic.Entities = &profitbricks.NicEntities{
+			FirewallRules: &profitbricks.FirewallRules{
+				Items: []profitbricks.FirewallRule{
+					GetFirewallResource(d, path+".firewall.0"),
 				},
+			},
+		}
+	}
+
+	return nic
+}
+
+// serverNicIds returns the ids of a nic block and its firewall rule, the first nic falls back to primary_nic and firewallrule_id
+func serverNicIds(d *schema.ResourceData, raw interface{}, index int) (string, string) {
+	nicId, firewallId := "", ""
+	if nic, ok := raw.(map[string]interface{}); ok {
+		if v, ok := nic["id"].(string); ok {
+			nicId = v
+		}
+		if firewalls, ok := nic["firewall"].([]interface{}); ok && len(firewalls) > 0 {
+			if firewall, ok := firewalls[0].(map[string]interface{}); ok {
+				if v, ok := firewall["id"].(string); ok {
+					firewallId = v
+				}
 			}
 		}
-		mProp, _ := json.Marshal(properties)
-		log.Printf("[DEBUG] Updating props: %s", string(mProp))
-		nic, err := client.UpdateNic(d.Get("datacenter_id").(string), server.ID, nic.ID, properties)
+	}
+
+	if index == 0 {
+		if nicId == "" {
+			nicId = d.Get("primary_nic").(string)
+		}
+		if firewallId == "" {
+			firewallId = d.Get("firewallrule_id").(string)
+		}
+	}
+
+	return nicId, firewallId
+}
+
+// setServerNicIds stores the ids of the nics and their firewall rules, so they can be read back in the order of the configuration
+func setServerNicIds(d *schema.ResourceData, nicIds []string, firewallIds []string) {
+	nics := d.Get("nic").([]interface{})
+	for i, raw := range nics {
+		if i >= len(nicIds) {
+			break
+		}
+		nic := raw.(map[string]interface{})
+		nic["id"] = nicIds[i]
+		if firewalls, ok := nic["firewall"].([]interface{}); ok && len(firewalls) > 0 {
+			firewalls[0].(map[string]interface{})["id"] = firewallIds[i]
+		}
+	}
+	d.Set("nic", nics)
+
+	if len(nicIds) > 0 {
+		d.Set("primary_nic", nicIds[0])
+		d.Set("firewallrule_id", firewallIds[0])
+	}
+}
+
+// createServerNic adds the nic block at path to the server, returning the ids of the nic and its firewall rule
+func createServerNic(d *schema.ResourceData, meta interface{}, path string, timeoutType string) (string, string, error) {
+	client := meta.(*profitbricks.Client)
+	dcId := d.Get("datacenter_id").(string)
+
+	nic := getServerNic(d, path)
+	createdNic, err := client.CreateNic(dcId, d.Id(), nic)
+	if err != nil {
+		return "", "", fmt.Errorf("Error creating nic %s of server ID %s (%w)", path, d.Id(), err)
+	}
+
+	// Wait, catching any errors
+	_, errState := waitForRequest(meta, d, createdNic.Headers.Get("Location"), timeoutType)
+	if errState != nil {
+		return "", "", errState
+	}
+
+	firewallId := ""
+	if nic.Entities != nil {
+		firewallRules, err := client.ListFirewallRules(dcId, d.Id(), createdNic.ID)
 		if err != nil {
-			return fmt.Errorf(
-				"Error updating nic (%s)", err)
+			return "", "", fmt.Errorf("Error fetching firewall rules of nic %s (%w)", createdNic.ID, err)
+		}
+		if len(firewallRules.Items) > 0 {
+			firewallId = firewallRules.Items[0].ID
 		}
+	}
 
-		// Wait, catching any errors
-		_, errState := getStateChangeConf(meta, d, nic.Headers.Get("Location"), schema.TimeoutUpdate).WaitForState()
-		if errState != nil {
-			return errState
+	return createdNic.ID, firewallId, nil
+}
+
+// updateServerNic updates the nic block at path and its firewall rule, returning the id of the firewall rule
+func updateServerNic(d *schema.ResourceData, meta interface{}, path string, nicId string, firewallId string) (string, error) {
+	client := meta.(*profitbricks.Client)
+	dcId := d.Get("datacenter_id").(string)
+
+	properties := getServerNic(d, path).Properties
+	mProp, _ := json.Marshal(properties)
+	log.Printf("[DEBUG] Updating props: %s", string(mProp))
+	nic, err := client.UpdateNic(dcId, d.Id(), nicId, *properties)
+	if err != nil {
+		return firewallId, fmt.Errorf(
+			"Error updating nic (%s)", err)
+	}
+
+	// Wait, catching any errors
+	_, errState := waitForRequest(meta, d, nic.Headers.Get("Location"), schema.TimeoutUpdate)
+	if errState != nil {
+		return firewallId, errState
+	}
+
+	if !d.HasChange(path + ".firewall") {
+		return firewallId, nil
+	}
+
+	if _, ok := d.GetOk(path + ".firewall"); !ok {
+		if firewallId == "" {
+			return "", nil
+		}
+		resp, err := client.DeleteFirewallRule(dcId, d.Id(), nicId, firewallId)
+		if err != nil {
+			return firewallId, fmt.Errorf("Error deleting firewall rule %s of nic %s (%w)", firewallId, nicId, err)
 		}
+		_, errState = waitForRequest(meta, d, resp.Get("Location"), schema.TimeoutUpdate)
+		return "", errState
+	}
 
+	firewall := GetFirewallResource(d, path+".firewall.0")
+	if firewallId == "" {
+		created, err := client.CreateFirewallRule(dcId, d.Id(), nicId, firewall)
+		if err != nil {
+			return "", fmt.Errorf("Error creating firewall rule of nic %s (%w)", nicId, err)
+		}
+		_, errState = waitForRequest(meta, d, created.Headers.Get("Location"), schema.TimeoutUpdate)
+		return created.ID, errState
 	}
 
-	return resourceProfitBricksServerRead(d, meta)
+	updated, err := client.UpdateFirewallRule(dcId, d.Id(), nicId, firewallId, firewall.Properties)
+	if err != nil {
+		return firewallId, fmt.Errorf("Error updating firewall rule %s of nic %s (%w)", firewallId, nicId, err)
+	}
+	_, errState = waitForRequest(meta, d, updated.Headers.Get("Location"), schema.TimeoutUpdate)
+	return firewallId, errState
 }
 
-func resourceProfitBricksServerDelete(d *schema.ResourceData, meta interface{}) error {
+// updateServerBootDevice points the server to its configured boot device, clearing both devices boots it from the network
+func updateServerBootDevice(d *schema.ResourceData, meta interface{}, timeoutType string) error {
 	client := meta.(*profitbricks.Client)
 	dcId := d.Get("datacenter_id").(string)
 
-	server, err := client.GetServer(dcId, d.Id())
+	properties := map[string]interface{}{
+		"bootVolume": nil,
+		"bootCdrom":  nil,
+	}
+
+	if !d.Get("boot_from_network").(bool) {
+		if v, ok := d.GetOk("boot_cdrom"); ok && d.HasChange("boot_cdrom") {
+			properties["bootCdrom"] = profitbricks.ResourceReference{ID: v.(string)}
+		} else if v, ok := d.GetOk("boot_volume"); ok {
+			if _, err := client.GetAttachedVolume(dcId, d.Id(), v.(string)); err != nil {
+				if apiError, ok := err.(profitbricks.ApiError); ok && apiError.HttpStatusCode() == 404 {
+					return fmt.Errorf("Volume %s is not attached to server ID %s, attach it before booting from it", v, d.Id())
+				}
+				return fmt.Errorf("Error occured while fetching volume %s of server ID %s %w", v, d.Id(), err)
+			}
+			properties["bootVolume"] = profitbricks.ResourceReference{ID: v.(string)}
+		} else {
+			// going back from a network boot, the server boots from its first volume again
+			volumes, err := client.ListAttachedVolumes(dcId, d.Id())
+			if err != nil {
+				return fmt.Errorf("Error occured while fetching the volumes of server ID %s %w", d.Id(), err)
+			}
+			if len(volumes.Items) == 0 {
+				return fmt.Errorf("Server ID %s has no volume to boot from, set 'boot_cdrom' or enable 'boot_from_network'", d.Id())
+			}
+			properties["bootVolume"] = profitbricks.ResourceReference{ID: volumes.Items[0].ID}
+		}
+	}
 
+	log.Printf("[INFO] Changing boot device of server ID %s to %+v", d.Id(), properties)
+	server := &profitbricks.Server{}
+	err := client.Patch(fmt.Sprintf("/datacenters/%s/servers/%s", dcId, d.Id()), properties, server, http.StatusAccepted)
 	if err != nil {
-		return fmt.Errorf("Error occured while fetching a server ID %s %s", d.Id(), err)
+		return fmt.Errorf("Error occured while changing the boot device of server ID %s %w", d.Id(), err)
 	}
 
-	if server.Properties.BootVolume != nil {
-		resp, err := client.DeleteVolume(dcId, server.Properties.BootVolume.ID)
+	// Wait, catching any errors
+	_, errState := waitForRequest(meta, d, server.Headers.Get("Location"), timeoutType)
+	return errState
+}
+
+// attachServerCdroms attaches CD-ROM images to a server, making sure they are of type CDROM
+func attachServerCdroms(d *schema.ResourceData, meta interface{}, cdromIds []interface{}, timeoutType string) error {
+	client := meta.(*profitbricks.Client)
+	dcId := d.Get("datacenter_id").(string)
+
+	for _, cdromId := range cdromIds {
+		img, err := client.GetImage(cdromId.(string))
 		if err != nil {
-			return fmt.Errorf("Error occured while delete volume %s of server ID %s %s", server.Properties.BootVolume.ID, d.Id(), err)
+			return fmt.Errorf("Error fetching CD-ROM image %s: %w", cdromId, err)
+		}
+
+		if img.Properties.ImageType != "CDROM" {
+			return fmt.Errorf("Image %s is of type %s, only images of type CDROM can be attached as CD-ROM", cdromId, img.Properties.ImageType)
 		}
+
+		cdrom, err := client.AttachCdrom(dcId, d.Id(), cdromId.(string))
+		if err != nil {
+			return fmt.Errorf("An error occured while attaching CD-ROM %s to server ID %s %w", cdromId, d.Id(), err)
+		}
+
 		// Wait, catching any errors
-		_, errState := getStateChangeConf(meta, d, resp.Get("Location"), schema.TimeoutDelete).WaitForState()
+		_, errState := waitForRequest(meta, d, cdrom.Headers.Get("Location"), timeoutType)
 		if errState != nil {
 			return errState
 		}
 	}
 
-	resp, err := client.DeleteServer(dcId, d.Id())
+	return nil
+}
+
+// updateServerVmState starts or stops the server according to vm_state and waits until the vm has reached that state
+func updateServerVmState(d *schema.ResourceData, meta interface{}, timeoutType string) error {
+	return changeServerVmState(d, meta, d.Get("vm_state").(string), timeoutType)
+}
+
+// changeServerVmState starts or stops the server and waits until the vm has reached the given state
+func changeServerVmState(d *schema.ResourceData, meta interface{}, vmState string, timeoutType string) error {
+	return changeServerVmStateByID(d, meta, d.Get("datacenter_id").(string), d.Id(), vmState, timeoutType)
+}
+
+// startServerAfterFailure starts the server serverId, which was stopped for a change failing with err, again so
+// it is not left powered off, and returns err
+func startServerAfterFailure(d *schema.ResourceData, meta interface{}, dcId string, serverId string, timeoutType string, err error) error {
+	log.Printf("[WARN] Starting server %s again after a failed change: %s", serverId, err)
+	if startErr := changeServerVmStateByID(d, meta, dcId, serverId, "RUNNING", timeoutType); startErr != nil {
+		return fmt.Errorf("%w. Starting the stopped server %s again failed as well: %s", err, serverId, startErr)
+	}
+	return err
+}
+
+// changeServerVmStateByID starts or stops the server serverId, e.g. the server of a nic, and waits until the vm
+// has reached the given state. d only provides the timeouts
+func changeServerVmStateByID(d *schema.ResourceData, meta interface{}, dcId string, serverId string, vmState string, timeoutType string) error {
+	client := meta.(*profitbricks.Client)
+
+	var resp *http.Header
+	var err error
+	if vmState == "SHUTOFF" {
+		log.Printf("[INFO] Stopping server %s", serverId)
+		resp, err = client.StopServer(dcId, serverId)
+	} else {
+		log.Printf("[INFO] Starting server %s", serverId)
+		resp, err = client.StartServer(dcId, serverId)
+	}
 	if err != nil {
-		return fmt.Errorf("An error occured while deleting a server ID %s %s", d.Id(), err)
+		return fmt.Errorf("Error while changing the vm state of server %s to %s: %w", serverId, vmState, err)
+	}
+
+	// Wait, catching any errors
+	_, errState := waitForRequest(meta, d, resp.Get("Location"), timeoutType)
+	if errState != nil {
+		return errState
+	}
+
+	return waitForServerVmStateByID(d, meta, dcId, serverId, vmState, timeoutType)
+}
 
+// rebootServer reboots the server and waits until it is running again
+func rebootServer(d *schema.ResourceData, meta interface{}, timeoutType string) error {
+	client := meta.(*profitbricks.Client)
+
+	log.Printf("[INFO] Rebooting server %s", d.Id())
+	resp, err := client.RebootServer(d.Get("datacenter_id").(string), d.Id())
+	if err != nil {
+		return fmt.Errorf("Error while rebooting server %s: %w", d.Id(), err)
 	}
 
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, resp.Get("Location"), schema.TimeoutDelete).WaitForState()
+	_, errState := waitForRequest(meta, d, resp.Get("Location"), timeoutType)
 	if errState != nil {
 		return errState
 	}
 
-	d.SetId("")
+	return waitForServerVmState(d, meta, "RUNNING", timeoutType)
+}
+
+// serverMissingHotPlugCapabilities lists the hot plug capabilities a running server lacks to change its cores and ram as configured
+func serverMissingHotPlugCapabilities(d *schema.ResourceData, meta interface{}) ([]string, error) {
+	client := meta.(*profitbricks.Client)
+	dcId := d.Get("datacenter_id").(string)
+
+	server, err := client.GetServer(dcId, d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("Error occured while fetching server ID %s %w", d.Id(), err)
+	}
+
+	// a stopped server picks up the new resources when it is started
+	if server.Properties.VMState != "RUNNING" || server.Properties.BootVolume == nil {
+		return nil, nil
+	}
+
+	volume, err := client.GetVolume(dcId, server.Properties.BootVolume.ID)
+	if err != nil {
+		return nil, fmt.Errorf("Error occured while fetching the boot volume of server ID %s %w", d.Id(), err)
+	}
+
+	missing := []string{}
+	cores := d.Get("cores").(int)
+	if cores > server.Properties.Cores && !volume.Properties.CPUHotPlug {
+		missing = append(missing, "cpu hot plug")
+	}
+	if cores < server.Properties.Cores && !volume.Properties.CPUHotUnplug {
+		missing = append(missing, "cpu hot unplug")
+	}
+	ram := d.Get("ram").(int)
+	if ram > server.Properties.RAM && !volume.Properties.RAMHotPlug {
+		missing = append(missing, "ram hot plug")
+	}
+	if ram < server.Properties.RAM && !volume.Properties.RAMHotUnplug {
+		missing = append(missing, "ram hot unplug")
+	}
+
+	return missing, nil
+}
+
+// waitForServerResources waits until the server reports the configured cores and ram, which
+// may happen some time after the request changing them is done
+func waitForServerResources(d *schema.ResourceData, meta interface{}, timeoutType string) error {
+	client := meta.(*profitbricks.Client)
+	dcId := d.Get("datacenter_id").(string)
+	cores := d.Get("cores").(int)
+	ram := d.Get("ram").(int)
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"RESIZING"},
+		Target:  []string{"RESIZED"},
+		Refresh: func() (interface{}, string, error) {
+			server, err := client.GetServer(dcId, d.Id())
+			if err != nil {
+				return nil, "", fmt.Errorf("Error checking cores and ram of server %s: %w", d.Id(), err)
+			}
+			if server.Properties.Cores != cores || server.Properties.RAM != ram {
+				log.Printf("[INFO] Server %s has %d cores and %d MB ram, waiting for %d cores and %d MB", d.Id(), server.Properties.Cores, server.Properties.RAM, cores, ram)
+				return server, "RESIZING", nil
+			}
+			return server, "RESIZED", nil
+		},
+		Timeout:    d.Timeout(timeoutType),
+		MinTimeout: pollInterval(client, 5*time.Second),
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error while waiting for server %s to have %d cores and %d MB ram: %w", d.Id(), cores, ram, err)
+	}
+
 	return nil
 }
 
+// waitForServerVmState waits for the vm of the server to reach the given state, which
+// happens some time after the request changing it is done
+func waitForServerVmState(d *schema.ResourceData, meta interface{}, vmState string, timeoutType string) error {
+	return waitForServerVmStateByID(d, meta, d.Get("datacenter_id").(string), d.Id(), vmState, timeoutType)
+}
+
+// waitForServerVmStateByID waits until the server serverId reports vmState, d only provides the timeouts
+func waitForServerVmStateByID(d *schema.ResourceData, meta interface{}, dcId string, serverId string, vmState string, timeoutType string) error {
+	client := meta.(*profitbricks.Client)
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    serverVmStates,
+		Target:     []string{vmState},
+		Refresh:    serverVmStateRefreshFunc(client, dcId, serverId),
+		Timeout:    d.Timeout(timeoutType),
+		MinTimeout: pollInterval(client, 5*time.Second),
+		Delay:      pollInterval(client, 5*time.Second),
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error while waiting for server %s to reach vm state %s: %w", serverId, vmState, err)
+	}
+
+	return nil
+}
+
+// serverVmStates lists the vm states a server can report
+var serverVmStates = []string{"NOSTATE", "RUNNING", "BLOCKED", "PAUSED", "SHUTDOWN", "SHUTOFF", "CRASHED"}
+
+// serverVmStateRefreshFunc reports the vm state of a server
+func serverVmStateRefreshFunc(client *profitbricks.Client, dcId string, serverId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		server, err := client.GetServer(dcId, serverId)
+		if err != nil {
+			return nil, "", fmt.Errorf("Error checking vm state of server %s: %w", serverId, err)
+		}
+		return server, server.Properties.VMState, nil
+	}
+}
+
 // Reads public key from file and returns key string iff valid
 func readPublicKey(path string) (key string, err error) {
 	bytes, err := ioutil.ReadFile(path)
@@ -2,6 +2,8 @@ package profitbricks
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
@@ -26,6 +28,8 @@ func TestAccProfitBricksServer_Basic(t *testing.T) {
 					testAccCheckProfitBricksServerExists("profitbricks_server.webserver", &server),
 					testAccCheckProfitBricksServerAttributes("profitbricks_server.webserver", serverName),
 					resource.TestCheckResourceAttr("profitbricks_server.webserver", "name", serverName),
+					resource.TestCheckResourceAttrPair("profitbricks_server.webserver", "primary_nic", "profitbricks_server.webserver", "nic.0.id"),
+					resource.TestCheckResourceAttrPair("profitbricks_server.webserver", "primary_ip", "profitbricks_server.webserver", "primary_ips.0"),
 				),
 			},
 			{
@@ -49,6 +53,36 @@ func TestAccProfitBricksServer_Basic(t *testing.T) {
 	})
 }
 
+func TestAccProfitBricksServer_Rename(t *testing.T) {
+	var serverId string
+	resourceName := "profitbricks_server.webserver"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksServerDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckProfitbricksServerConfig_basic, "webserver"),
+				Check: func(s *terraform.State) error {
+					serverId = s.RootModule().Resources[resourceName].Primary.ID
+					return nil
+				},
+			},
+			{
+				// a name only change updates the server in place
+				Config: fmt.Sprintf(testAccCheckProfitbricksServerConfig_basic, "renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(resourceName, "id", &serverId),
+					resource.TestCheckResourceAttr(resourceName, "name", "renamed"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckDProfitBricksServerDestroyCheck(s *terraform.State) error {
 	client := testAccProvider.Meta().(*profitbricks.Client)
 	for _, rs := range s.RootModule().Resources {
@@ -229,6 +263,473 @@ resource "profitbricks_server" "webserver" {
   }
 }`
 
+func TestAccProfitBricksServer_Cdroms(t *testing.T) {
+	var server profitbricks.Server
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksServerDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckProfitbricksServerConfig_cdroms, `cdroms = ["${data.profitbricks_image.rescue.id}"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksServerExists("profitbricks_server.webserver", &server),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "cdroms.#", "1"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckProfitbricksServerConfig_cdroms, `boot_from_network = true`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksServerExists("profitbricks_server.webserver", &server),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "boot_from_network", "true"),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "boot_volume", ""),
+					resource.TestCheckResourceAttrSet("profitbricks_server.webserver", "inline_volume_id"),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "volume.0.name", "system"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckProfitbricksServerConfig_cdroms, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksServerExists("profitbricks_server.webserver", &server),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "boot_from_network", "false"),
+					resource.TestCheckResourceAttrSet("profitbricks_server.webserver", "boot_volume"),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "cdroms.#", "0"),
+				),
+			},
+			{
+				// the CD-ROM is attached before the server is pointed to it
+				Config: fmt.Sprintf(testAccCheckProfitbricksServerConfig_cdroms, `cdroms = ["${data.profitbricks_image.rescue.id}"]
+  boot_cdrom = "${data.profitbricks_image.rescue.id}"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksServerExists("profitbricks_server.webserver", &server),
+					resource.TestCheckResourceAttrPair("profitbricks_server.webserver", "boot_cdrom", "data.profitbricks_image.rescue", "id"),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "boot_volume", ""),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckProfitbricksServerConfig_cdroms = `
+resource "profitbricks_datacenter" "foobar" {
+	name       = "server-test"
+	location = "us/las"
+}
+
+data "profitbricks_image" "rescue" {
+  name     = "ubuntu"
+  type     = "CDROM"
+  location = "us/las"
+}
+
+resource "profitbricks_lan" "webserver_lan" {
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  public = true
+  name = "public"
+}
+
+resource "profitbricks_server" "webserver" {
+  name = "webserver"
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  cores = 1
+  ram = 1024
+  availability_zone = "ZONE_1"
+  cpu_family = "AMD_OPTERON"
+	image_name ="ubuntu:latest"
+	image_password = "K3tTj8G14a3EgKyNeeiY"
+  %s
+  volume {
+    name = "system"
+    size = 5
+    disk_type = "SSD"
+}
+  nic {
+    lan = "${profitbricks_lan.webserver_lan.id}"
+    dhcp = true
+    firewall_active = true
+  }
+}`
+
+func TestAccProfitBricksServer_AttachedVolumes(t *testing.T) {
+	var server profitbricks.Server
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksServerDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckProfitbricksServerConfig_attachedVolumes, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksServerExists("profitbricks_server.webserver", &server),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "attached_volumes.#", "2"),
+					resource.TestCheckResourceAttrPair("profitbricks_server.webserver", "attached_volumes.0.id", "profitbricks_server.webserver", "boot_volume"),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "attached_volumes.0.boot", "true"),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "attached_volumes.1.boot", "false"),
+				),
+			},
+			{
+				Config:      fmt.Sprintf(testAccCheckProfitbricksServerConfig_attachedVolumes, `boot_volume = "00000000-0000-0000-0000-000000000000"`),
+				ExpectError: regexp.MustCompile("is not attached to server"),
+			},
+		},
+	})
+}
+
+const testAccCheckProfitbricksServerConfig_attachedVolumes = `
+resource "profitbricks_datacenter" "foobar" {
+	name       = "server-test"
+	location = "us/las"
+}
+
+resource "profitbricks_server" "webserver" {
+  name = "webserver"
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  cores = 1
+  ram = 1024
+  availability_zone = "ZONE_1"
+  cpu_family = "AMD_OPTERON"
+	image_name ="ubuntu:latest"
+	image_password = "K3tTj8G14a3EgKyNeeiY"
+  %s
+  volume {
+    name = "system"
+    size = 5
+    disk_type = "SSD"
+  }
+}
+
+resource "profitbricks_volume" "data" {
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  server_id = "${profitbricks_server.webserver.id}"
+  licence_type = "OTHER"
+  name = "data"
+  size = 5
+  disk_type = "HDD"
+}`
+
+func TestAccProfitBricksServer_MultipleNics(t *testing.T) {
+	var server profitbricks.Server
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksServerDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckProfitbricksServerConfig_multipleNics,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksServerExists("profitbricks_server.webserver", &server),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "nic.#", "2"),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "nic.1.name", "private"),
+					resource.TestCheckResourceAttrSet("profitbricks_server.webserver", "nic.1.id"),
+					resource.TestCheckResourceAttrSet("profitbricks_server.webserver", "nic.1.firewall.0.id"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckProfitbricksServerConfig_basic, "webserver"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksServerExists("profitbricks_server.webserver", &server),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "nic.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckProfitbricksServerConfig_multipleNics = `
+resource "profitbricks_datacenter" "foobar" {
+	name       = "server-test"
+	location = "us/las"
+}
+
+resource "profitbricks_lan" "webserver_lan" {
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  public = true
+  name = "public"
+}
+
+resource "profitbricks_lan" "private_lan" {
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  public = false
+  name = "private"
+}
+
+resource "profitbricks_server" "webserver" {
+  name = "webserver"
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  cores = 1
+  ram = 1024
+  availability_zone = "ZONE_1"
+  cpu_family = "AMD_OPTERON"
+	image_name ="ubuntu:latest"
+	image_password = "K3tTj8G14a3EgKyNeeiY"
+  volume {
+    name = "system"
+    size = 5
+    disk_type = "SSD"
+}
+  nic {
+    lan = "${profitbricks_lan.webserver_lan.id}"
+    dhcp = true
+    firewall_active = true
+		firewall {
+      protocol = "TCP"
+      name = "SSH"
+      port_range_start = 22
+      port_range_end = 22
+    }
+  }
+  nic {
+    lan = "${profitbricks_lan.private_lan.id}"
+    name = "private"
+    dhcp = true
+    firewall_active = true
+		firewall {
+      protocol = "TCP"
+      name = "postgres"
+      port_range_start = 5432
+      port_range_end = 5432
+    }
+  }
+}`
+
+func TestAccProfitBricksServer_VmState(t *testing.T) {
+	var server profitbricks.Server
+	serverName := "webserver"
+	shutOffConfig := strings.Replace(fmt.Sprintf(testAccCheckProfitbricksServerConfig_basic, serverName), "cores = 1", "cores = 1\n  vm_state = \"SHUTOFF\"", 1)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksServerDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckProfitbricksServerConfig_basic, serverName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksServerExists("profitbricks_server.webserver", &server),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "vm_state", "RUNNING"),
+				),
+			},
+			{
+				Config: shutOffConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksServerExists("profitbricks_server.webserver", &server),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "vm_state", "SHUTOFF"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckProfitbricksServerConfig_basic, serverName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksServerExists("profitbricks_server.webserver", &server),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "vm_state", "RUNNING"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProfitBricksServer_RebootOnChange(t *testing.T) {
+	var server profitbricks.Server
+	serverName := "webserver"
+	rebootConfig := func(revision string) string {
+		return strings.Replace(fmt.Sprintf(testAccCheckProfitbricksServerConfig_basic, serverName), "cores = 1", fmt.Sprintf("cores = 1\n  reboot_on_change = {\n    revision = \"%s\"\n  }", revision), 1)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksServerDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: rebootConfig("1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksServerExists("profitbricks_server.webserver", &server),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "reboot_on_change.revision", "1"),
+				),
+			},
+			{
+				Config: rebootConfig("2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksServerExists("profitbricks_server.webserver", &server),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "reboot_on_change.revision", "2"),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "vm_state", "RUNNING"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProfitBricksServer_Resize(t *testing.T) {
+	var server profitbricks.Server
+	serverName := "webserver"
+	resizedConfig := strings.Replace(fmt.Sprintf(testAccCheckProfitbricksServerConfig_basic, serverName), "cores = 1\n  ram = 1024", "cores = 2\n  ram = 2048\n  reboot_on_resize = true", 1)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksServerDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckProfitbricksServerConfig_basic, serverName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksServerExists("profitbricks_server.webserver", &server),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "cores", "1"),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "ram", "1024"),
+				),
+			},
+			{
+				Config: resizedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksServerExists("profitbricks_server.webserver", &server),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "cores", "2"),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "ram", "2048"),
+					testAccCheckProfitBricksServerResources("profitbricks_server.webserver", 2, 2048),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckProfitBricksServerResources checks the cores and ram the API reports for the server
+func testAccCheckProfitBricksServerResources(n string, cores int, ram int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*profitbricks.Client)
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		server, err := client.GetServer(rs.Primary.Attributes["datacenter_id"], rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error occured while fetching server: %s", rs.Primary.ID)
+		}
+		if server.Properties.Cores != cores || server.Properties.RAM != ram {
+			return fmt.Errorf("Server %s has %d cores and %d MB ram, expected %d cores and %d MB", rs.Primary.ID, server.Properties.Cores, server.Properties.RAM, cores, ram)
+		}
+		return nil
+	}
+}
+
+func TestAccProfitBricksServer_CpuFamily(t *testing.T) {
+	var serverId string
+	serverName := "webserver"
+	cpuFamilyConfig := func(cpuFamily string) string {
+		return strings.Replace(fmt.Sprintf(testAccCheckProfitbricksServerConfig_basic, serverName), `cpu_family = "AMD_OPTERON"`, fmt.Sprintf(`cpu_family = "%s"`, cpuFamily), 1)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksServerDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: cpuFamilyConfig("AMD_OPTERON"),
+				Check: func(s *terraform.State) error {
+					serverId = s.RootModule().Resources["profitbricks_server.webserver"].Primary.ID
+					return nil
+				},
+			},
+			{
+				Config: cpuFamilyConfig("INTEL_XEON"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("profitbricks_server.webserver", "id", &serverId),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "cpu_family", "INTEL_XEON"),
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "vm_state", "RUNNING"),
+				),
+			},
+			{
+				Config:      cpuFamilyConfig("NOT_A_CPU_FAMILY"),
+				ExpectError: regexp.MustCompile("is not offered in location"),
+			},
+		},
+	})
+}
+
+func TestAccProfitBricksServer_DefaultCpuFamily(t *testing.T) {
+	serverConfig := strings.Replace(fmt.Sprintf(testAccCheckProfitbricksServerConfig_basic, "webserver"), `cpu_family = "AMD_OPTERON"`, "", 1)
+	// the data center has to exist for the plan to show the cpu family of the new server
+	datacenterConfig := serverConfig[:strings.Index(serverConfig, `resource "profitbricks_server"`)]
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksServerDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: datacenterConfig,
+			},
+			{
+				Config: serverConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("profitbricks_server.webserver", "cpu_family", "AMD_OPTERON"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProfitBricksServer_CheckCapacity(t *testing.T) {
+	serverConfig := strings.Replace(fmt.Sprintf(testAccCheckProfitbricksServerConfig_basic, "webserver"), "cores = 1\n", "cores = 1000\n", 1)
+	// the data center has to exist for the plan to know the location of the server
+	datacenterConfig := serverConfig[:strings.Index(serverConfig, `resource "profitbricks_server"`)]
+	provider := `
+provider "profitbricks" {
+	check_capacity = true
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksServerDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: provider + datacenterConfig,
+			},
+			{
+				Config:      provider + serverConfig,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`1000 cores exceed`),
+			},
+		},
+	})
+}
+
+func TestAccProfitBricksServer_InvalidAvailabilityZone(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      strings.Replace(fmt.Sprintf(testAccCheckProfitbricksServerConfig_basic, "webserver"), `availability_zone = "ZONE_1"`, `availability_zone = "ZONE_3"`, 1),
+				ExpectError: regexp.MustCompile(`must be one of AUTO, ZONE_1 or ZONE_2`),
+			},
+		},
+	})
+}
+
 func Test_Update(t *testing.T) {
 
 }
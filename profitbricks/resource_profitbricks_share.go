@@ -45,22 +45,22 @@ func resourceProfitBricksShareCreate(d *schema.ResourceData, meta interface{}) e
 		Properties: profitbricks.ShareProperties{},
 	}
 
-	tempSharePrivilege := d.Get("edit_privilege").(bool)
-	request.Properties.SharePrivilege = &tempSharePrivilege
-	tempEditPrivilege := d.Get("share_privilege").(bool)
+	tempEditPrivilege := d.Get("edit_privilege").(bool)
 	request.Properties.EditPrivilege = &tempEditPrivilege
+	tempSharePrivilege := d.Get("share_privilege").(bool)
+	request.Properties.SharePrivilege = &tempSharePrivilege
 
 	share, err := client.AddShare(d.Get("group_id").(string), d.Get("resource_id").(string), request)
 
 	log.Printf("[DEBUG] SHARE ID: %s", share.ID)
 
 	if err != nil {
-		return fmt.Errorf("An error occured while creating a share: %s", err)
+		return fmt.Errorf("An error occured while creating a share: %w", err)
 	}
 	d.SetId(share.ID)
 
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, share.Headers.Get("Location"), schema.TimeoutCreate).WaitForState()
+	_, errState := waitForRequest(meta, d, share.Headers.Get("Location"), schema.TimeoutCreate)
 	if errState != nil {
 		if IsRequestFailed(err) {
 			// Request failed, so resource was not created, delete resource from state file
@@ -83,7 +83,7 @@ func resourceProfitBricksShareRead(d *schema.ResourceData, meta interface{}) err
 				return nil
 			}
 		}
-		return fmt.Errorf("An error occured while fetching a Share ID %s %s", d.Id(), err)
+		return fmt.Errorf("An error occured while fetching a Share ID %s %w", d.Id(), err)
 	}
 
 	d.Set("edit_privilege", share.Properties.EditPrivilege)
@@ -93,6 +93,7 @@ func resourceProfitBricksShareRead(d *schema.ResourceData, meta interface{}) err
 
 func resourceProfitBricksShareUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*profitbricks.Client)
+	// both privileges are always sent, the share is replaced in place by a PUT
 	tempSharePrivilege := d.Get("share_privilege").(bool)
 	tempEditPrivilege := d.Get("edit_privilege").(bool)
 	shareReq := profitbricks.Share{
@@ -104,11 +105,11 @@ func resourceProfitBricksShareUpdate(d *schema.ResourceData, meta interface{}) e
 
 	share, err := client.UpdateShare(d.Get("group_id").(string), d.Get("resource_id").(string), shareReq)
 	if err != nil {
-		return fmt.Errorf("An error occured while patching a share ID %s %s", d.Id(), err)
+		return fmt.Errorf("An error occured while patching a share ID %s %w", d.Id(), err)
 	}
 
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, share.Headers.Get("Location"), schema.TimeoutUpdate).WaitForState()
+	_, errState := waitForRequest(meta, d, share.Headers.Get("Location"), schema.TimeoutUpdate)
 	if errState != nil {
 		return errState
 	}
@@ -118,15 +119,15 @@ func resourceProfitBricksShareUpdate(d *schema.ResourceData, meta interface{}) e
 
 func resourceProfitBricksShareDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*profitbricks.Client)
-	resp, err := client.DeleteShare(d.Id(), d.Get("resource_id").(string))
+	resp, err := client.DeleteShare(d.Get("group_id").(string), d.Get("resource_id").(string))
 	if err != nil {
 		//try again in 20 seconds
 		time.Sleep(20 * time.Second)
-		resp, err = client.DeleteShare(d.Id(), d.Get("resource_id").(string))
+		resp, err = client.DeleteShare(d.Get("group_id").(string), d.Get("resource_id").(string))
 		if err != nil {
 			if apiError, ok := err.(profitbricks.ApiError); ok {
 				if apiError.HttpStatusCode() != 404 {
-					return fmt.Errorf("An error occured while deleting a share %s %s", d.Id(), err)
+					return fmt.Errorf("An error occured while deleting a share %s %w", d.Id(), err)
 				}
 			}
 		}
@@ -134,7 +135,7 @@ func resourceProfitBricksShareDelete(d *schema.ResourceData, meta interface{}) e
 
 	// Wait, catching any errors
 	if resp.Get("Location") != "" {
-		_, errState := getStateChangeConf(meta, d, resp.Get("Location"), schema.TimeoutDelete).WaitForState()
+		_, errState := waitForRequest(meta, d, resp.Get("Location"), schema.TimeoutDelete)
 		if errState != nil {
 			return errState
 		}
@@ -28,6 +28,8 @@ func TestAccProfitBricksShare_Basic(t *testing.T) {
 			{
 				Config: testAccCheckProfitbricksShareConfig_update,
 				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksShareExists("profitbricks_share.share", &share),
+					resource.TestCheckResourceAttr("profitbricks_share.share", "edit_privilege", "true"),
 					resource.TestCheckResourceAttr("profitbricks_share.share", "share_privilege", "false"),
 				),
 			},
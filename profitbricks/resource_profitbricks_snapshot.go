@@ -2,8 +2,11 @@ package profitbricks
 
 import (
 	"fmt"
+	"log"
+	"net/http"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
 )
@@ -14,22 +17,45 @@ func resourceProfitBricksSnapshot() *schema.Resource {
 		Read:   resourceProfitBricksSnapshotRead,
 		Update: resourceProfitBricksSnapshotUpdate,
 		Delete: resourceProfitBricksSnapshotDelete,
-		Schema: map[string]*schema.Schema{
+		Importer: &schema.ResourceImporter{
+			State: resourceProfitBricksSnapshotImport,
+		},
+		Schema: withHotPlugSchema(map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			// the API does not tell which volume a snapshot was taken from, so both are
+			// optional and ignored for imported snapshots
 			"volume_id": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressSnapshotSourceDiff,
 			},
 			"datacenter_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressSnapshotSourceDiff,
+			},
+			"description": {
 				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Computed: true,
 			},
-		},
+			"location": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"licence_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		}, volumeHotPlugProperties),
 		Timeouts: &resourceDefaultTimeouts,
 	}
 }
@@ -40,15 +66,19 @@ func resourceProfitBricksSnapshotCreate(d *schema.ResourceData, meta interface{}
 	volumeId := d.Get("volume_id").(string)
 	name := d.Get("name").(string)
 
+	if dcId == "" || volumeId == "" {
+		return fmt.Errorf("'datacenter_id' and 'volume_id' must be set to create a snapshot")
+	}
+
 	snapshot, err := client.CreateSnapshot(dcId, volumeId, name, "")
 
 	if err != nil {
-		return fmt.Errorf("An error occured while creating a snapshot: %s", err)
+		return fmt.Errorf("An error occured while creating a snapshot: %w", err)
 	}
 
 	d.SetId(snapshot.ID)
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, snapshot.Headers.Get("Location"), schema.TimeoutCreate).WaitForState()
+	_, errState := waitForRequest(meta, d, snapshot.Headers.Get("Location"), schema.TimeoutCreate)
 	if errState != nil {
 		if IsRequestFailed(err) {
 			// Request failed, so resource was not created, delete resource from state file
@@ -57,6 +87,11 @@ func resourceProfitBricksSnapshotCreate(d *schema.ResourceData, meta interface{}
 		return errState
 	}
 
+	// snapshots inherit the capability flags of their volume, configured flags are applied afterwards
+	if err := updateSnapshotHotPlugFlags(d, meta, schema.TimeoutCreate); err != nil {
+		return err
+	}
+
 	return resourceProfitBricksSnapshotRead(d, meta)
 }
 
@@ -71,70 +106,114 @@ func resourceProfitBricksSnapshotRead(d *schema.ResourceData, meta interface{})
 				return nil
 			}
 		}
-		return fmt.Errorf("Error occured while fetching a snapshot ID %s %s", d.Id(), err)
+		return fmt.Errorf("Error occured while fetching a snapshot ID %s %w", d.Id(), err)
 	}
 
 	d.Set("name", snapshot.Properties.Name)
+	d.Set("description", snapshot.Properties.Description)
+	d.Set("location", snapshot.Properties.Location)
+	d.Set("licence_type", snapshot.Properties.LicenceType)
+	d.Set("size", snapshot.Properties.Size)
+	d.Set("cpu_hot_plug", snapshot.Properties.CPUHotPlug)
+	d.Set("ram_hot_plug", snapshot.Properties.RAMHotPlug)
+	d.Set("nic_hot_plug", snapshot.Properties.NicHotPlug)
+	d.Set("nic_hot_unplug", snapshot.Properties.NicHotUnplug)
+	d.Set("disc_virtio_hot_plug", snapshot.Properties.DiscVirtioHotPlug)
+	d.Set("disc_virtio_hot_unplug", snapshot.Properties.DiscVirtioHotUnplug)
+	d.Set("disc_scsi_hot_plug", snapshot.Properties.DiscScsiHotPlug)
 	return nil
 }
 
 func resourceProfitBricksSnapshotUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*profitbricks.Client)
-	dcId := d.Get("datacenter_id").(string)
-	volumeId := d.Get("volume_id").(string)
 
-	snapshot, err := client.RestoreSnapshot(dcId, volumeId, d.Id())
-	if err != nil {
-		return fmt.Errorf("An error occured while restoring a snapshot ID %s %d", d.Id(), err)
+	if d.HasChange("name") {
+		// only the name is sent, the sdk model leaves out the unset properties
+		snapshot, err := client.UpdateSnapshot(d.Id(), profitbricks.SnapshotProperties{Name: d.Get("name").(string)})
+		if err != nil {
+			return fmt.Errorf("An error occured while updating a snapshot ID %s %w", d.Id(), err)
+		}
+
+		// Wait, catching any errors
+		_, errState := waitForRequest(meta, d, snapshot.Headers.Get("Location"), schema.TimeoutUpdate)
+		if errState != nil {
+			return errState
+		}
 	}
 
-	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, snapshot.Get("Location"), schema.TimeoutUpdate).WaitForState()
-	if errState != nil {
-		return errState
+	if err := updateSnapshotHotPlugFlags(d, meta, schema.TimeoutUpdate); err != nil {
+		return err
 	}
 
 	return resourceProfitBricksSnapshotRead(d, meta)
 }
 
+// suppressSnapshotSourceDiff ignores a volume or data center being set on a snapshot which was imported without one
+func suppressSnapshotSourceDiff(k, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != "" && old == ""
+}
+
+// updateSnapshotHotPlugFlags patches the changed capability flags of a snapshot
+func updateSnapshotHotPlugFlags(d *schema.ResourceData, meta interface{}, timeoutType string) error {
+	client := meta.(*profitbricks.Client)
+
+	properties := changedProperties(d, volumeHotPlugProperties)
+	if len(properties) == 0 {
+		return nil
+	}
+
+	log.Printf("[INFO] Updating capability flags of snapshot %s to %+v", d.Id(), properties)
+	snapshot := &profitbricks.Snapshot{}
+	err := client.Patch(fmt.Sprintf("/snapshots/%s", d.Id()), properties, snapshot, http.StatusAccepted)
+	if err != nil {
+		return fmt.Errorf("An error occured while updating a snapshot ID %s %w", d.Id(), err)
+	}
+
+	// Wait, catching any errors
+	_, errState := waitForRequest(meta, d, snapshot.Headers.Get("Location"), timeoutType)
+	return errState
+}
+
 func resourceProfitBricksSnapshotDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*profitbricks.Client)
 	status, err := client.GetSnapshot(d.Id())
 	if err != nil {
-		return fmt.Errorf("An error occured while fetching a snapshot ID %s %s", d.Id(), err)
+		return fmt.Errorf("An error occured while fetching a snapshot ID %s %w", d.Id(), err)
 	}
 	for status.Metadata.State != "AVAILABLE" {
-		time.Sleep(30 * time.Second)
+		time.Sleep(pollInterval(client, 30*time.Second))
 		status, err = client.GetSnapshot(d.Id())
 
 		if err != nil {
-			return fmt.Errorf("An error occured while fetching a snapshot ID %s %s", d.Id(), err)
+			return fmt.Errorf("An error occured while fetching a snapshot ID %s %w", d.Id(), err)
 		}
 	}
 
-	dcId := d.Get("datacenter_id").(string)
-	dc, err := client.GetDatacenter(dcId)
+	// imported snapshots do not know their data center, there is nothing to wait for then
+	if dcId := d.Get("datacenter_id").(string); dcId != "" {
+		dc, err := client.GetDatacenter(dcId)
 
-	if err != nil {
-		return fmt.Errorf("An error occured while fetching a Datacenter ID %s %s", dcId, err)
-	}
+		if err != nil {
+			return fmt.Errorf("An error occured while fetching a Datacenter ID %s %w", dcId, err)
+		}
 
-	for dc.Metadata.State != "AVAILABLE" {
-		time.Sleep(30 * time.Second)
-		dc, err = client.GetDatacenter(dcId)
+		for dc.Metadata.State != "AVAILABLE" {
+			time.Sleep(pollInterval(client, 30*time.Second))
+			dc, err = client.GetDatacenter(dcId)
 
-		if err != nil {
-			return fmt.Errorf("An error occured while fetching a Datacenter ID %s %s", dcId, err)
+			if err != nil {
+				return fmt.Errorf("An error occured while fetching a Datacenter ID %s %w", dcId, err)
+			}
 		}
 	}
 
 	resp, err := client.DeleteSnapshot(d.Id())
 	if err != nil {
-		return fmt.Errorf("An error occured while deleting a snapshot ID %s %s", d.Id(), err)
+		return fmt.Errorf("An error occured while deleting a snapshot ID %s %w", d.Id(), err)
 	}
 
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, resp.Get("Location"), schema.TimeoutDelete).WaitForState()
+	_, errState := waitForRequest(meta, d, resp.Get("Location"), schema.TimeoutDelete)
 	if errState != nil {
 		return errState
 	}
@@ -142,3 +221,29 @@ func resourceProfitBricksSnapshotDelete(d *schema.ResourceData, meta interface{}
 	d.SetId("")
 	return nil
 }
+
+// waitForSnapshotAvailable waits until a snapshot a volume is restored from is AVAILABLE, a snapshot which is
+// still being taken cannot be restored even when the request creating it is done
+func waitForSnapshotAvailable(d *schema.ResourceData, meta interface{}, snapshotId string, timeoutType string) error {
+	client := meta.(*profitbricks.Client)
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"BUSY", "DEPLOYING", "UPDATING", "INACTIVE"},
+		Target:  []string{"AVAILABLE"},
+		Refresh: func() (interface{}, string, error) {
+			snapshot, err := client.GetSnapshot(snapshotId)
+			if err != nil {
+				return nil, "", fmt.Errorf("An error occured while fetching a snapshot ID %s %w", snapshotId, err)
+			}
+			log.Printf("[INFO] Snapshot %s is %s", snapshotId, snapshot.Metadata.State)
+			return snapshot, snapshot.Metadata.State, nil
+		},
+		Timeout:    d.Timeout(timeoutType),
+		MinTimeout: pollInterval(client, 10*time.Second),
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error while waiting for snapshot %s to become AVAILABLE: %w", snapshotId, err)
+	}
+
+	return nil
+}
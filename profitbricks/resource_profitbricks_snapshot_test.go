@@ -2,13 +2,47 @@ package profitbricks
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
 )
 
+func TestWaitForSnapshotAvailable(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/snapshots/snapshot-id" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		if calls < 3 {
+			w.Write([]byte(`{"id": "snapshot-id", "metadata": {"state": "BUSY"}}`))
+			return
+		}
+		w.Write([]byte(`{"id": "snapshot-id", "metadata": {"state": "AVAILABLE"}}`))
+	}))
+	defer server.Close()
+
+	config := Config{Token: "token", Endpoint: server.URL, PollInterval: 1}
+	client, err := config.Client("0.12")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceProfitBricksVolume().Schema, map[string]interface{}{})
+	if err := waitForSnapshotAvailable(d, client, "snapshot-id", schema.TimeoutCreate); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls)
+	}
+}
+
 func TestAccProfitBricksSnapshot_Basic(t *testing.T) {
 	var snapshot profitbricks.Snapshot
 	snapshotName := "terraform_snapshot"
@@ -31,6 +65,7 @@ func TestAccProfitBricksSnapshot_Basic(t *testing.T) {
 				Config: testAccCheckProfitbricksSnapshotConfig_update,
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("profitbricks_snapshot.test_snapshot", "name", snapshotName),
+					resource.TestCheckResourceAttr("profitbricks_snapshot.test_snapshot", "nic_hot_unplug", "false"),
 				),
 			},
 		},
@@ -163,4 +198,5 @@ resource "profitbricks_snapshot" "test_snapshot" {
   datacenter_id = "${profitbricks_datacenter.foobar.id}"
   volume_id = "${profitbricks_server.webserver.boot_volume}"
   name = "terraform_snapshot"
+  nic_hot_unplug = false
 }`
@@ -3,6 +3,7 @@ package profitbricks
 import (
 	"fmt"
 	"log"
+	"net/http"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -29,8 +30,9 @@ func resourceProfitBricksUser() *schema.Resource {
 				Required: true,
 			},
 			"password": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
 			},
 			"administrator": {
 				Type:     schema.TypeBool,
@@ -40,6 +42,21 @@ func resourceProfitBricksUser() *schema.Resource {
 				Type:     schema.TypeBool,
 				Required: true,
 			},
+			"sec_auth_active": {
+				Type:        schema.TypeBool,
+				Description: "Whether the user has actually set up two factor authentication",
+				Computed:    true,
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Description: "Whether the user is active, inactive users cannot log in or use the API",
+				Optional:    true,
+				Default:     true,
+			},
+			"s3_canonical_user_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 		Timeouts: &resourceDefaultTimeouts,
 	}
@@ -47,8 +64,8 @@ func resourceProfitBricksUser() *schema.Resource {
 
 func resourceProfitBricksUserCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*profitbricks.Client)
-	request := profitbricks.User{
-		Properties: &profitbricks.UserProperties{},
+	request := userWithExtras{
+		Properties: &userPropertiesWithExtras{},
 	}
 
 	log.Printf("[DEBUG] NAME %s", d.Get("first_name"))
@@ -66,20 +83,21 @@ func resourceProfitBricksUserCreate(d *schema.ResourceData, meta interface{}) er
 		request.Properties.Password = d.Get("password").(string)
 	}
 
-	request.Properties.Administrator = d.Get("administrator").(bool)
-	request.Properties.ForceSecAuth = d.Get("force_sec_auth").(bool)
-	user, err := client.CreateUser(request)
+	request.Properties.Administrator = boolAddr(d.Get("administrator").(bool))
+	request.Properties.ForceSecAuth = boolAddr(d.Get("force_sec_auth").(bool))
+	request.Properties.Active = boolAddr(d.Get("active").(bool))
+	user, err := createUserWithExtras(client, request)
 
 	log.Printf("[DEBUG] USER ID: %s", user.ID)
 
 	if err != nil {
-		return fmt.Errorf("An error occured while creating a user: %s", err)
+		return fmt.Errorf("An error occured while creating a user: %w", err)
 	}
 
 	d.SetId(user.ID)
 
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, user.Headers.Get("Location"), schema.TimeoutCreate).WaitForState()
+	_, errState := waitForRequest(meta, d, user.Headers.Get("Location"), schema.TimeoutCreate)
 	if errState != nil {
 		if IsRequestFailed(err) {
 			// Request failed, so resource was not created, delete resource from state file
@@ -92,7 +110,7 @@ func resourceProfitBricksUserCreate(d *schema.ResourceData, meta interface{}) er
 
 func resourceProfitBricksUserRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*profitbricks.Client)
-	user, err := client.GetUser(d.Id())
+	user, err := getUserWithExtras(client, d.Id())
 
 	if err != nil {
 		if apiError, ok := err.(profitbricks.ApiError); ok {
@@ -101,7 +119,7 @@ func resourceProfitBricksUserRead(d *schema.ResourceData, meta interface{}) erro
 				return nil
 			}
 		}
-		return fmt.Errorf("An error occured while fetching a User ID %s %s", d.Id(), err)
+		return fmt.Errorf("An error occured while fetching a User ID %s %w", d.Id(), err)
 	}
 
 	d.Set("first_name", user.Properties.Firstname)
@@ -109,6 +127,14 @@ func resourceProfitBricksUserRead(d *schema.ResourceData, meta interface{}) erro
 	d.Set("email", user.Properties.Email)
 	d.Set("administrator", user.Properties.Administrator)
 	d.Set("force_sec_auth", user.Properties.ForceSecAuth)
+	d.Set("sec_auth_active", user.Properties.SecAuthActive)
+	d.Set("s3_canonical_user_id", user.Properties.S3CanonicalUserId)
+	// users created before the api reported this flag are active
+	if user.Properties.Active != nil {
+		d.Set("active", *user.Properties.Active)
+	} else {
+		d.Set("active", true)
+	}
 	return nil
 }
 
@@ -117,13 +143,15 @@ func resourceProfitBricksUserUpdate(d *schema.ResourceData, meta interface{}) er
 	originalUser, err := client.GetUser(d.Id())
 
 	if err != nil {
-		return fmt.Errorf("An error occured while fetching a User ID %s %s", d.Id(), err)
+		return fmt.Errorf("An error occured while fetching a User ID %s %w", d.Id(), err)
 	}
 
-	userReq := profitbricks.User{
-		Properties: &profitbricks.UserProperties{
-			Administrator: d.Get("administrator").(bool),
-			ForceSecAuth:  d.Get("force_sec_auth").(bool),
+	// the password is left out of the request, so the api keeps the current one
+	userReq := userWithExtras{
+		Properties: &userPropertiesWithExtras{
+			Administrator: boolAddr(d.Get("administrator").(bool)),
+			ForceSecAuth:  boolAddr(d.Get("force_sec_auth").(bool)),
+			Active:        boolAddr(d.Get("active").(bool)),
 		},
 	}
 
@@ -149,13 +177,13 @@ func resourceProfitBricksUserUpdate(d *schema.ResourceData, meta interface{}) er
 		userReq.Properties.Email = originalUser.Properties.Email
 	}
 
-	user, err := client.UpdateUser(d.Id(), userReq)
+	user, err := updateUserWithExtras(client, d.Id(), userReq)
 	if err != nil {
-		return fmt.Errorf("An error occured while patching a user ID %s %s", d.Id(), err)
+		return fmt.Errorf("An error occured while patching a user ID %s %w", d.Id(), err)
 	}
 
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, user.Headers.Get("Location"), schema.TimeoutUpdate).WaitForState()
+	_, errState := waitForRequest(meta, d, user.Headers.Get("Location"), schema.TimeoutUpdate)
 	if errState != nil {
 		return errState
 	}
@@ -173,14 +201,14 @@ func resourceProfitBricksUserDelete(d *schema.ResourceData, meta interface{}) er
 		if err != nil {
 			if apiError, ok := err.(profitbricks.ApiError); ok {
 				if apiError.HttpStatusCode() != 404 {
-					return fmt.Errorf("An error occured while deleting a user %s %s", d.Id(), err)
+					return fmt.Errorf("An error occured while deleting a user %s %w", d.Id(), err)
 				}
 			}
 		}
 	}
 
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, resp.Get("Location"), schema.TimeoutDelete).WaitForState()
+	_, errState := waitForRequest(meta, d, resp.Get("Location"), schema.TimeoutDelete)
 	if errState != nil {
 		return errState
 	}
@@ -188,3 +216,37 @@ func resourceProfitBricksUserDelete(d *schema.ResourceData, meta interface{}) er
 	d.SetId("")
 	return nil
 }
+
+// userPropertiesWithExtras adds the user properties which are not modeled by profitbricks-sdk-go yet.
+// The flags are pointers, so turning them off is not dropped from the request
+type userPropertiesWithExtras struct {
+	profitbricks.UserProperties
+	Administrator     *bool  `json:"administrator,omitempty"`
+	ForceSecAuth      *bool  `json:"forceSecAuth,omitempty"`
+	S3CanonicalUserId string `json:"s3CanonicalUserId,omitempty"`
+	Active            *bool  `json:"active,omitempty"`
+}
+
+type userWithExtras struct {
+	ID         string                    `json:"id,omitempty"`
+	Properties *userPropertiesWithExtras `json:"properties,omitempty"`
+	Headers    *http.Header              `json:"headers,omitempty"`
+}
+
+func createUserWithExtras(client *profitbricks.Client, user userWithExtras) (*userWithExtras, error) {
+	ret := &userWithExtras{}
+	err := client.Post("/um/users", user, ret, http.StatusAccepted)
+	return ret, err
+}
+
+func getUserWithExtras(client *profitbricks.Client, userId string) (*userWithExtras, error) {
+	ret := &userWithExtras{}
+	err := client.Get(fmt.Sprintf("/um/users/%s", userId), ret, http.StatusOK)
+	return ret, err
+}
+
+func updateUserWithExtras(client *profitbricks.Client, userId string, user userWithExtras) (*userWithExtras, error) {
+	ret := &userWithExtras{}
+	err := client.Put(fmt.Sprintf("/um/users/%s", userId), user, ret, http.StatusAccepted)
+	return ret, err
+}
@@ -32,6 +32,9 @@ func TestAccProfitBricksUser_Basic(t *testing.T) {
 					testAccCheckProfitBricksUserExists("profitbricks_user.user", &user),
 					testAccCheckProfitBricksUserAttributes("profitbricks_user.user", "terraform"),
 					resource.TestCheckResourceAttr("profitbricks_user.user", "first_name", "terraform"),
+					resource.TestCheckResourceAttr("profitbricks_user.user", "active", "true"),
+					resource.TestCheckResourceAttr("profitbricks_user.user", "sec_auth_active", "false"),
+					resource.TestCheckResourceAttrSet("profitbricks_user.user", "s3_canonical_user_id"),
 				),
 			},
 			{
@@ -39,6 +42,7 @@ func TestAccProfitBricksUser_Basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckProfitBricksUserAttributes("profitbricks_user.user", "updated"),
 					resource.TestCheckResourceAttr("profitbricks_user.user", "first_name", "updated"),
+					resource.TestCheckResourceAttr("profitbricks_user.user", "active", "false"),
 				),
 			},
 		},
@@ -129,6 +133,7 @@ resource "profitbricks_user" "user" {
   password = "abc123-321CBA"
   administrator = false
   force_sec_auth= false
+  active = false
 }
 
 resource "profitbricks_group" "group" {
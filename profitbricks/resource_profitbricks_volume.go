@@ -1,11 +1,15 @@
 package profitbricks
 
 import (
+	"encoding/base64"
 	"fmt"
 	"log"
+	"net/http"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
+	"golang.org/x/crypto/ssh"
 )
 
 func resourceProfitBricksVolume() *schema.Resource {
@@ -14,7 +18,11 @@ func resourceProfitBricksVolume() *schema.Resource {
 		Read:   resourceProfitBricksVolumeRead,
 		Update: resourceProfitBricksVolumeUpdate,
 		Delete: resourceProfitBricksVolumeDelete,
-		Schema: map[string]*schema.Schema{
+		Importer: &schema.ResourceImporter{
+			State: resourceProfitBricksVolumeImport,
+		},
+		CustomizeDiff: resourceProfitBricksVolumeCustomizeDiff,
+		Schema: withHotPlugSchema(map[string]*schema.Schema{
 			"image_name": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -34,12 +42,18 @@ func resourceProfitBricksVolume() *schema.Resource {
 			"licence_type": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 			},
 			"ssh_key_path": {
 				Type:     schema.TypeList,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Optional: true,
 			},
+			"ssh_keys": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+			},
 			"sshkey": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -47,6 +61,13 @@ func resourceProfitBricksVolume() *schema.Resource {
 			"bus": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Default:  "VIRTIO",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if v.(string) != "VIRTIO" && v.(string) != "IDE" {
+						errors = append(errors, fmt.Errorf("%q must be VIRTIO or IDE, got %q", k, v.(string)))
+					}
+					return
+				},
 			},
 			"name": {
 				Type:     schema.TypeString,
@@ -55,6 +76,7 @@ func resourceProfitBricksVolume() *schema.Resource {
 			"availability_zone": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 			},
 			"server_id": {
 				Type:     schema.TypeString,
@@ -65,11 +87,52 @@ func resourceProfitBricksVolume() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
-		},
+			"backup_unit_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"user_data": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"user_data_base64": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				ForceNew: true,
+			},
+			"labels": labelsSchema(),
+		}, volumeHotPlugProperties),
 		Timeouts: &resourceDefaultTimeouts,
 	}
 }
 
+// resourceProfitBricksVolumeCustomizeDiff rejects shrinking a volume, volumes can only grow in place
+func resourceProfitBricksVolumeCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("size") {
+		return nil
+	}
+
+	oldSize, newSize := diff.GetChange("size")
+	if newSize.(int) < oldSize.(int) {
+		return fmt.Errorf("The size of volume %s cannot be decreased from %d GB to %d GB, volumes can only grow", diff.Id(), oldSize.(int), newSize.(int))
+	}
+	return nil
+}
+
+// volumeHotPlugProperties maps the capability flags of volumes and snapshots to their API property names
+var volumeHotPlugProperties = map[string]string{
+	"cpu_hot_plug":           "cpuHotPlug",
+	"ram_hot_plug":           "ramHotPlug",
+	"nic_hot_plug":           "nicHotPlug",
+	"nic_hot_unplug":         "nicHotUnplug",
+	"disc_virtio_hot_plug":   "discVirtioHotPlug",
+	"disc_virtio_hot_unplug": "discVirtioHotUnplug",
+	"disc_scsi_hot_plug":     "discScsiHotPlug",
+}
+
 func resourceProfitBricksVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*profitbricks.Client)
 
@@ -96,6 +159,12 @@ func resourceProfitBricksVolumeCreate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
+	sshKeys, err := readSSHKeys(d.Get("ssh_keys").([]interface{}))
+	if err != nil {
+		return err
+	}
+	publicKeys = append(publicKeys, sshKeys...)
+
 	var image string
 	if image_alias == "" && image_name != "" {
 		if !IsValidUUID(image_name) {
@@ -115,7 +184,7 @@ func resourceProfitBricksVolumeCreate(d *schema.ResourceData, meta interface{})
 					dc, err := client.GetDatacenter(dcId)
 
 					if err != nil {
-						return fmt.Errorf("An error occured while fetching a Datacenter ID %s %s", dcId, err)
+						return fmt.Errorf("An error occured while fetching a Datacenter ID %s %w", dcId, err)
 					}
 
 					image_alias = getImageAlias(client, image_name, dc.Properties.Location)
@@ -125,29 +194,41 @@ func resourceProfitBricksVolumeCreate(d *schema.ResourceData, meta interface{})
 			if image == "" && image_alias == "" {
 				return fmt.Errorf("Could not find an image/imagealias/snapshot that matches %s ", image_name)
 			}
-			if imagePassword == "" && len(ssh_keypath) == 0 && isSnapshot == false && img != nil && img.Properties.Public {
-				return fmt.Errorf("Either 'image_password' or 'sshkey' must be provided.")
+			if imagePassword == "" && len(publicKeys) == 0 && isSnapshot == false && img != nil && img.Properties.Public {
+				return fmt.Errorf("Either 'image_password', 'ssh_key_path' or 'ssh_keys' must be provided.")
 			}
 		} else {
+			// the uuid may either reference an image or a snapshot to restore the volume from
 			img, err := client.GetImage(image_name)
 			if err != nil {
-				_, err := client.GetSnapshot(image_name)
-				if err != nil {
-					return fmt.Errorf("Error fetching image/snapshot: %s", err)
+				if apiError, ok := err.(profitbricks.ApiError); !ok || apiError.HttpStatusCode() != 404 {
+					return fmt.Errorf("Error fetching image %s: %w", image_name, err)
 				}
-				isSnapshot = true
-			}
-			if img.Properties.Public == true && isSnapshot == false {
-				if imagePassword == "" && len(ssh_keypath) == 0 {
-					return fmt.Errorf("Either 'image_password' or 'sshkey' must be provided.")
+				if _, err := client.GetSnapshot(image_name); err != nil {
+					return fmt.Errorf("Error fetching image/snapshot: %w", err)
 				}
-				image = image_name
-			} else {
-				image = image_name
+				isSnapshot = true
+			} else if img.Properties.Public && imagePassword == "" && len(publicKeys) == 0 {
+				return fmt.Errorf("Either 'image_password', 'ssh_key_path' or 'ssh_keys' must be provided.")
 			}
+			image = image_name
+		}
+	}
+
+	if isSnapshot && licenceType == "" {
+		snapshot, err := client.GetSnapshot(image)
+		if err != nil {
+			return fmt.Errorf("Error fetching snapshot %s: %w", image, err)
+		}
+		if snapshot.Properties.LicenceType == "" || snapshot.Properties.LicenceType == "UNKNOWN" {
+			return fmt.Errorf("Snapshot %s has no licence type, 'licence_type' must be set to restore a volume from it", image)
 		}
 	}
 
+	if image_name == "" && (imagePassword != "" || len(publicKeys) > 0) {
+		return fmt.Errorf("'image_password', 'ssh_key_path' and 'ssh_keys' can only be used together with 'image_name'")
+	}
+
 	if image_name == "" && licenceType == "" && isSnapshot == false {
 		return fmt.Errorf("Either 'image_name', or 'licenceType' must be set.")
 	}
@@ -156,18 +237,53 @@ func resourceProfitBricksVolumeCreate(d *schema.ResourceData, meta interface{})
 		return fmt.Errorf("You can't pass 'image_password' and/or 'ssh keys' when creating a volume from a snapshot")
 	}
 
-	volume := &profitbricks.Volume{
-		Properties: profitbricks.VolumeProperties{
-			Name:          d.Get("name").(string),
-			Size:          d.Get("size").(int),
-			Type:          d.Get("disk_type").(string),
-			ImagePassword: imagePassword,
-			Image:         image,
-			ImageAlias:    image_alias,
-			Bus:           d.Get("bus").(string),
-			LicenceType:   licenceType,
+	if isSnapshot {
+		if err := waitForSnapshotAvailable(d, meta, image, schema.TimeoutCreate); err != nil {
+			return err
+		}
+	}
+
+	backupUnitId := d.Get("backup_unit_id").(string)
+	if backupUnitId != "" && ((image == "" && image_alias == "") || isSnapshot == true) {
+		return fmt.Errorf("'backup_unit_id' can only be set on a volume created from a public image or an image alias, 'image_name' must reference one")
+	}
+
+	userData := d.Get("user_data").(string)
+	if userData != "" {
+		if (image == "" && image_alias == "") || isSnapshot == true {
+			return fmt.Errorf("'user_data' can only be set on a volume created from a public image or an image alias supporting cloud-init, 'image_name' must reference one")
+		}
+		if err := checkImageCloudInit(client, dcId, image, image_alias); err != nil {
+			return err
+		}
+		if userData, err = encodeUserData(userData, d.Get("user_data_base64").(bool)); err != nil {
+			return err
+		}
+	}
+
+	for attr := range volumeHotPlugProperties {
+		if _, ok := d.GetOkExists(attr); ok && image == "" && image_alias == "" {
+			return fmt.Errorf("'%s' can only be set on a volume created from an image, 'image_name' must reference one", attr)
+		}
+	}
+
+	volume := &volumeWithExtras{
+		Properties: volumePropertiesWithExtras{
+			VolumeProperties: profitbricks.VolumeProperties{
+				Name:          d.Get("name").(string),
+				Size:          d.Get("size").(int),
+				Type:          d.Get("disk_type").(string),
+				ImagePassword: imagePassword,
+				Image:         image,
+				ImageAlias:    image_alias,
+				Bus:           d.Get("bus").(string),
+				LicenceType:   licenceType,
+			},
+			BackupUnitID: backupUnitId,
+			UserData:     userData,
 		},
 	}
+	setVolumeHotPlugFlags(d, &volume.Properties)
 
 	if len(publicKeys) != 0 {
 		volume.Properties.SSHKeys = publicKeys
@@ -181,16 +297,16 @@ func resourceProfitBricksVolumeCreate(d *schema.ResourceData, meta interface{})
 		volume.Properties.AvailabilityZone = raw
 	}
 
-	volume, err := client.CreateVolume(dcId, *volume)
+	createdVolume, err := createVolumeWithExtras(client, dcId, *volume)
 
 	if err != nil {
-		return fmt.Errorf("An error occured while creating a volume: %s", err)
+		return fmt.Errorf("An error occured while creating a volume: %w", err)
 	}
 
-	d.SetId(volume.ID)
+	d.SetId(createdVolume.ID)
 
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, volume.Headers.Get("Location"), schema.TimeoutCreate).WaitForState()
+	_, errState := waitForRequest(meta, d, createdVolume.Headers.Get("Location"), schema.TimeoutCreate)
 	if errState != nil {
 		if IsRequestFailed(err) {
 			// Request failed, so resource was not created, delete resource from state file
@@ -199,14 +315,18 @@ func resourceProfitBricksVolumeCreate(d *schema.ResourceData, meta interface{})
 		return errState
 	}
 
-	volume, err = client.AttachVolume(dcId, serverId, volume.ID)
+	if err := updateLabels(client, d, volumeLabelsPath(dcId, d.Id())); err != nil {
+		return err
+	}
+
+	attachedVolume, err := client.AttachVolume(dcId, serverId, createdVolume.ID)
 	if err != nil {
-		return fmt.Errorf("An error occured while attaching a volume dcId: %s server_id: %s ID: %s Response: %s", dcId, serverId, volume.ID, err)
+		return fmt.Errorf("An error occured while attaching a volume dcId: %s server_id: %s ID: %s Response: %w", dcId, serverId, createdVolume.ID, err)
 	}
 
 	d.Set("server_id", serverId)
 	// Wait, catching any errors
-	_, errState = getStateChangeConf(meta, d, volume.Headers.Get("Location"), schema.TimeoutCreate).WaitForState()
+	_, errState = waitForRequest(meta, d, attachedVolume.Headers.Get("Location"), schema.TimeoutCreate)
 	if errState != nil {
 		if IsRequestFailed(err) {
 			// Request failed, so resource was not created, delete resource from state file
@@ -224,7 +344,7 @@ func resourceProfitBricksVolumeRead(d *schema.ResourceData, meta interface{}) er
 	serverID := d.Get("server_id").(string)
 	volumeID := d.Id()
 
-	volume, err := client.GetVolume(dcId, d.Id())
+	volume, err := getVolumeWithExtras(client, dcId, d.Id())
 
 	if err != nil {
 		if apiError, ok := err.(profitbricks.ApiError); ok {
@@ -233,12 +353,7 @@ func resourceProfitBricksVolumeRead(d *schema.ResourceData, meta interface{}) er
 				return nil
 			}
 		}
-		return fmt.Errorf("Error occured while fetching a volume ID %s %s", d.Id(), err)
-	}
-
-	if volume.StatusCode > 299 {
-		return fmt.Errorf("An error occured while fetching a volume ID %s %s", d.Id(), volume.Response)
-
+		return fmt.Errorf("Error occured while fetching a volume ID %s %w", d.Id(), err)
 	}
 
 	_, err = client.GetAttachedVolume(dcId, serverID, volumeID)
@@ -250,15 +365,36 @@ func resourceProfitBricksVolumeRead(d *schema.ResourceData, meta interface{}) er
 	d.Set("disk_type", volume.Properties.Type)
 	d.Set("size", volume.Properties.Size)
 	d.Set("bus", volume.Properties.Bus)
+	d.Set("licence_type", volume.Properties.LicenceType)
+	d.Set("availability_zone", volume.Properties.AvailabilityZone)
 	d.Set("image_name", volume.Properties.Image)
 	d.Set("image_alias", volume.Properties.ImageAlias)
+	d.Set("backup_unit_id", volume.Properties.BackupUnitID)
+
+	for attr, value := range map[string]*bool{
+		"cpu_hot_plug":           volume.Properties.CPUHotPlug,
+		"ram_hot_plug":           volume.Properties.RAMHotPlug,
+		"nic_hot_plug":           volume.Properties.NicHotPlug,
+		"nic_hot_unplug":         volume.Properties.NicHotUnplug,
+		"disc_virtio_hot_plug":   volume.Properties.DiscVirtioHotPlug,
+		"disc_virtio_hot_unplug": volume.Properties.DiscVirtioHotUnplug,
+		"disc_scsi_hot_plug":     volume.Properties.DiscScsiHotPlug,
+	} {
+		if value != nil {
+			d.Set(attr, *value)
+		}
+	}
+
+	if err := readLabels(client, d, volumeLabelsPath(dcId, d.Id())); err != nil {
+		return err
+	}
 
 	return nil
 }
 
 func resourceProfitBricksVolumeUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*profitbricks.Client)
-	properties := profitbricks.VolumeProperties{}
+	properties := volumePropertiesWithExtras{}
 	dcId := d.Get("datacenter_id").(string)
 
 	if d.HasChange("name") {
@@ -270,33 +406,51 @@ func resourceProfitBricksVolumeUpdate(d *schema.ResourceData, meta interface{})
 		properties.Type = newValue.(string)
 	}
 	if d.HasChange("size") {
-		_, newValue := d.GetChange("size")
+		oldValue, newValue := d.GetChange("size")
+		if newValue.(int) < oldValue.(int) {
+			return fmt.Errorf("The size of volume %s cannot be decreased from %d GB to %d GB, volumes can only grow", d.Id(), oldValue.(int), newValue.(int))
+		}
+		log.Printf("[INFO] Resizing volume %s from %d GB to %d GB", d.Id(), oldValue.(int), newValue.(int))
 		properties.Size = newValue.(int)
 	}
 	if d.HasChange("bus") {
-		_, newValue := d.GetChange("bus")
+		oldValue, newValue := d.GetChange("bus")
+		// the volume is still attached to the old server, a new one only gets it afterwards
+		serverId, _ := d.GetChange("server_id")
+		missing, err := volumeMissingBusHotPlugCapabilities(client, dcId, serverId.(string), d.Id(), oldValue.(string), newValue.(string))
+		if err != nil {
+			return err
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("Volume ID %s is attached to the running server %s and does not support %s, so its bus cannot change from %s to %s while the server is running. Stop the server by setting its 'vm_state' to SHUTOFF for the change", d.Id(), serverId.(string), strings.Join(missing, ", "), oldValue.(string), newValue.(string))
+		}
 		properties.Bus = newValue.(string)
 	}
 	if d.HasChange("availability_zone") {
 		_, newValue := d.GetChange("availability_zone")
 		properties.AvailabilityZone = newValue.(string)
 	}
+	setVolumeHotPlugFlags(d, &properties)
+
+	body, err := clearedProperties(properties, clearedName(d, "name")...)
+	if err != nil {
+		return err
+	}
 
-	volume, err := client.UpdateVolume(dcId, d.Id(), properties)
+	volume, err := updateVolumeWithExtras(client, dcId, d.Id(), body)
 
 	if err != nil {
-		return fmt.Errorf("An error occured while updating a volume ID %s %s", d.Id(), err)
+		return fmt.Errorf("An error occured while updating a volume ID %s %w", d.Id(), err)
 	}
 
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, volume.Headers.Get("Location"), schema.TimeoutUpdate).WaitForState()
+	_, errState := waitForRequest(meta, d, volume.Headers.Get("Location"), schema.TimeoutUpdate)
 	if errState != nil {
 		return errState
 	}
 
-	if volume.StatusCode > 299 {
-		return fmt.Errorf("An error occured while updating a volume ID %s %s", d.Id(), volume.Response)
-
+	if err := updateLabels(client, d, volumeLabelsPath(dcId, d.Id())); err != nil {
+		return err
 	}
 
 	if d.HasChange("server_id") {
@@ -304,11 +458,11 @@ func resourceProfitBricksVolumeUpdate(d *schema.ResourceData, meta interface{})
 		serverID := newValue.(string)
 		volumeAttach, err := client.AttachVolume(dcId, serverID, volume.ID)
 		if err != nil {
-			return fmt.Errorf("An error occured while attaching a volume dcId: %s server_id: %s ID: %s Response: %s", dcId, serverID, volumeAttach.ID, err)
+			return fmt.Errorf("An error occured while attaching a volume dcId: %s server_id: %s ID: %s Response: %w", dcId, serverID, volumeAttach.ID, err)
 		}
 
 		// Wait, catching any errors
-		_, errState = getStateChangeConf(meta, d, volumeAttach.Headers.Get("Location"), schema.TimeoutCreate).WaitForState()
+		_, errState = waitForRequest(meta, d, volumeAttach.Headers.Get("Location"), schema.TimeoutCreate)
 		if errState != nil {
 			return errState
 		}
@@ -323,12 +477,12 @@ func resourceProfitBricksVolumeDelete(d *schema.ResourceData, meta interface{})
 
 	resp, err := client.DeleteVolume(dcId, d.Id())
 	if err != nil {
-		return fmt.Errorf("An error occured while deleting a volume ID %s %s", d.Id(), err)
+		return fmt.Errorf("An error occured while deleting a volume ID %s %w", d.Id(), err)
 
 	}
 
 	// Wait, catching any errors
-	_, errState := getStateChangeConf(meta, d, resp.Get("Location"), schema.TimeoutDelete).WaitForState()
+	_, errState := waitForRequest(meta, d, resp.Get("Location"), schema.TimeoutDelete)
 	if errState != nil {
 		return errState
 	}
@@ -336,3 +490,159 @@ func resourceProfitBricksVolumeDelete(d *schema.ResourceData, meta interface{})
 	d.SetId("")
 	return nil
 }
+
+// volumePropertiesWithExtras adds the volume properties which are not modeled by profitbricks-sdk-go yet
+type volumePropertiesWithExtras struct {
+	profitbricks.VolumeProperties
+	BackupUnitID string `json:"backupunitId,omitempty"`
+	UserData     string `json:"userData,omitempty"`
+
+	// the capability flags shadow the ones of the sdk model, so flags which are turned off are sent as well
+	CPUHotPlug          *bool `json:"cpuHotPlug,omitempty"`
+	RAMHotPlug          *bool `json:"ramHotPlug,omitempty"`
+	NicHotPlug          *bool `json:"nicHotPlug,omitempty"`
+	NicHotUnplug        *bool `json:"nicHotUnplug,omitempty"`
+	DiscVirtioHotPlug   *bool `json:"discVirtioHotPlug,omitempty"`
+	DiscVirtioHotUnplug *bool `json:"discVirtioHotUnplug,omitempty"`
+	DiscScsiHotPlug     *bool `json:"discScsiHotPlug,omitempty"`
+}
+
+type volumeWithExtras struct {
+	ID         string                     `json:"id,omitempty"`
+	Properties volumePropertiesWithExtras `json:"properties"`
+	Headers    *http.Header               `json:"headers,omitempty"`
+}
+
+func createVolumeWithExtras(client *profitbricks.Client, dcId string, volume volumeWithExtras) (*volumeWithExtras, error) {
+	ret := &volumeWithExtras{}
+	err := client.Post(fmt.Sprintf("/datacenters/%s/volumes", dcId), volume, ret, http.StatusAccepted)
+	return ret, err
+}
+
+func updateVolumeWithExtras(client *profitbricks.Client, dcId string, volumeId string, properties interface{}) (*volumeWithExtras, error) {
+	ret := &volumeWithExtras{}
+	err := client.Patch(fmt.Sprintf("/datacenters/%s/volumes/%s", dcId, volumeId), properties, ret, http.StatusAccepted)
+	return ret, err
+}
+
+func getVolumeWithExtras(client *profitbricks.Client, dcId string, volumeId string) (*volumeWithExtras, error) {
+	ret := &volumeWithExtras{}
+	err := client.Get(fmt.Sprintf("/datacenters/%s/volumes/%s", dcId, volumeId), ret, http.StatusOK)
+	return ret, err
+}
+
+// setVolumeHotPlugFlags sets the capability flags which have to be sent to the API
+func setVolumeHotPlugFlags(d *schema.ResourceData, properties *volumePropertiesWithExtras) {
+	properties.CPUHotPlug = hotPlugFlag(d, "cpu_hot_plug")
+	properties.RAMHotPlug = hotPlugFlag(d, "ram_hot_plug")
+	properties.NicHotPlug = hotPlugFlag(d, "nic_hot_plug")
+	properties.NicHotUnplug = hotPlugFlag(d, "nic_hot_unplug")
+	properties.DiscVirtioHotPlug = hotPlugFlag(d, "disc_virtio_hot_plug")
+	properties.DiscVirtioHotUnplug = hotPlugFlag(d, "disc_virtio_hot_unplug")
+	properties.DiscScsiHotPlug = hotPlugFlag(d, "disc_scsi_hot_plug")
+}
+
+// encodeUserData base64 encodes cloud-init user data, unless isBase64 tells it already is
+func encodeUserData(userData string, isBase64 bool) (string, error) {
+	if !isBase64 {
+		return base64.StdEncoding.EncodeToString([]byte(userData)), nil
+	}
+	if _, err := base64.StdEncoding.DecodeString(userData); err != nil {
+		return "", fmt.Errorf("'user_data' is not base64 encoded although 'user_data_base64' is set: %w", err)
+	}
+	return userData, nil
+}
+
+// checkImageCloudInit checks that the image a volume is created from supports cloud-init. An image alias is
+// looked up among the images in the location of the data center, aliases which cannot be found are left to the API
+func checkImageCloudInit(client *profitbricks.Client, dcId string, imageId string, imageAlias string) error {
+	if imageId == "" {
+		dc, err := client.GetDatacenter(dcId)
+		if err != nil {
+			return fmt.Errorf("An error occured while fetching a Datacenter ID %s %w", dcId, err)
+		}
+		images, err := listImages(client)
+		if err != nil {
+			return fmt.Errorf("Error fetching the images for image alias %s: %w", imageAlias, err)
+		}
+		for _, img := range images.Items {
+			if img.Properties.Location != dc.Properties.Location {
+				continue
+			}
+			for _, alias := range img.Properties.ImageAliases {
+				if strings.EqualFold(alias, imageAlias) {
+					imageId = img.ID
+				}
+			}
+		}
+		if imageId == "" {
+			log.Printf("[WARN] Image alias %s not found in location %s, cloud-init support is not checked", imageAlias, dc.Properties.Location)
+			return nil
+		}
+	}
+
+	img, err := getImageWithExtras(client, imageId)
+	if err != nil {
+		return fmt.Errorf("Error fetching image %s: %w", imageId, err)
+	}
+	if img.Properties.CloudInit != "V1" {
+		return fmt.Errorf("'user_data' can only be set on a volume created from an image supporting cloud-init, image %s (%s) does not", img.Properties.Name, imageId)
+	}
+	return nil
+}
+
+// readSSHKeys accepts public keys as well as paths to files containing a public key
+func readSSHKeys(keys []interface{}) ([]string, error) {
+	var publicKeys []string
+	for _, key := range keys {
+		if pubKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key.(string))); err == nil {
+			publicKeys = append(publicKeys, string(ssh.MarshalAuthorizedKey(pubKey)[:]))
+			continue
+		}
+
+		log.Printf("[DEBUG] Reading file %s", key)
+		publicKey, err := readPublicKey(key.(string))
+		if err != nil {
+			return nil, fmt.Errorf("Error fetching sshkey from file (%s) (%s)", key, err.Error())
+		}
+		publicKeys = append(publicKeys, publicKey)
+	}
+	return publicKeys, nil
+}
+
+// volumeMissingBusHotPlugCapabilities lists the hot plug capabilities a volume attached to a running server lacks
+// to move from one bus to another, which unplugs it from the old bus and plugs it into the new one. IDE volumes
+// cannot be hot plugged at all
+func volumeMissingBusHotPlugCapabilities(client *profitbricks.Client, dcId string, serverId string, volumeId string, oldBus string, newBus string) ([]string, error) {
+	if serverId == "" {
+		return nil, nil
+	}
+
+	server, err := client.GetServer(dcId, serverId)
+	if err != nil {
+		if apiError, ok := err.(profitbricks.ApiError); ok && apiError.HttpStatusCode() == 404 {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("An error occured while fetching server ID %s %w", serverId, err)
+	}
+
+	// a stopped server picks up the new bus when it is started
+	if server.Properties.VMState != "RUNNING" {
+		return nil, nil
+	}
+
+	volume, err := client.GetVolume(dcId, volumeId)
+	if err != nil {
+		return nil, fmt.Errorf("An error occured while fetching a volume ID %s %w", volumeId, err)
+	}
+
+	missing := []string{}
+	if oldBus != "VIRTIO" || !volume.Properties.DiscVirtioHotUnplug {
+		missing = append(missing, fmt.Sprintf("%s hot unplug", strings.ToLower(oldBus)))
+	}
+	if newBus != "VIRTIO" || !volume.Properties.DiscVirtioHotPlug {
+		missing = append(missing, fmt.Sprintf("%s hot plug", strings.ToLower(newBus)))
+	}
+
+	return missing, nil
+}
@@ -2,6 +2,10 @@ package profitbricks
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
@@ -37,6 +41,213 @@ func TestAccProfitBricksVolume_Basic(t *testing.T) {
 	})
 }
 
+func TestAccProfitBricksVolume_Rename(t *testing.T) {
+	var volumeId string
+	resourceName := "profitbricks_volume.database_volume"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksVolumeDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckProfitbricksVolumeConfig_basic, "volume"),
+				Check: func(s *terraform.State) error {
+					volumeId = s.RootModule().Resources[resourceName].Primary.ID
+					return nil
+				},
+			},
+			{
+				// a name only change updates the volume in place
+				Config: fmt.Sprintf(testAccCheckProfitbricksVolumeConfig_basic, "renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(resourceName, "id", &volumeId),
+					resource.TestCheckResourceAttr(resourceName, "name", "renamed"),
+				),
+			},
+			{
+				// the name is cleared as well
+				Config: fmt.Sprintf(testAccCheckProfitbricksVolumeConfig_basic, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(resourceName, "id", &volumeId),
+					resource.TestCheckResourceAttr(resourceName, "name", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProfitBricksVolume_Resize(t *testing.T) {
+	var volumeId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksVolumeDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckProfitbricksVolumeConfig_resize, 5),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("profitbricks_volume.database_volume", "size", "5"),
+					func(s *terraform.State) error {
+						volumeId = s.RootModule().Resources["profitbricks_volume.database_volume"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckProfitbricksVolumeConfig_resize, 10),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("profitbricks_volume.database_volume", "size", "10"),
+					resource.TestCheckResourceAttrPtr("profitbricks_volume.database_volume", "id", &volumeId),
+				),
+			},
+			{
+				Config:      fmt.Sprintf(testAccCheckProfitbricksVolumeConfig_resize, 5),
+				ExpectError: regexp.MustCompile("volumes can only grow"),
+			},
+		},
+	})
+}
+
+func TestVolumeMissingBusHotPlugCapabilities(t *testing.T) {
+	vmState := "RUNNING"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/datacenters/dc/servers/server":
+			w.Write([]byte(`{"id": "server", "properties": {"vmState": "` + vmState + `"}}`))
+		case "/datacenters/dc/volumes/volume":
+			w.Write([]byte(`{"id": "volume", "properties": {"discVirtioHotPlug": true, "discVirtioHotUnplug": true}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	config := Config{Token: "token", Endpoint: server.URL}
+	client, err := config.Client("0.12")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	for _, tc := range []struct {
+		oldBus, newBus string
+		missing        []string
+	}{
+		{"VIRTIO", "IDE", []string{"ide hot plug"}},
+		{"IDE", "VIRTIO", []string{"ide hot unplug"}},
+	} {
+		missing, err := volumeMissingBusHotPlugCapabilities(client, "dc", "server", "volume", tc.oldBus, tc.newBus)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if !reflect.DeepEqual(missing, tc.missing) {
+			t.Errorf("expected %v from %s to %s, got %v", tc.missing, tc.oldBus, tc.newBus, missing)
+		}
+	}
+
+	vmState = "SHUTOFF"
+	missing, err := volumeMissingBusHotPlugCapabilities(client, "dc", "server", "volume", "VIRTIO", "IDE")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(missing) > 0 {
+		t.Errorf("expected a stopped server to allow any bus change, got %v", missing)
+	}
+}
+
+func TestEncodeUserData(t *testing.T) {
+	// plain text which happens to be valid base64 is encoded as well
+	encoded, err := encodeUserData("abcd", false)
+	if err != nil || encoded != "YWJjZA==" {
+		t.Fatalf("expected abcd to be encoded to YWJjZA==, got %q %v", encoded, err)
+	}
+
+	encoded, err = encodeUserData("YWJjZA==", true)
+	if err != nil || encoded != "YWJjZA==" {
+		t.Fatalf("expected base64 user data to be sent as it is, got %q %v", encoded, err)
+	}
+
+	if _, err := encodeUserData("#cloud-config", true); err == nil {
+		t.Fatalf("expected user data which is not base64 encoded to be rejected")
+	}
+}
+
+func TestCheckImageCloudInit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/datacenters/dc":
+			w.Write([]byte(`{"id": "dc", "properties": {"location": "de/fra"}}`))
+		case "/images":
+			w.Write([]byte(`{"items": [
+				{"id": "other-location", "properties": {"location": "us/las", "imageAliases": ["ubuntu:latest"]}},
+				{"id": "cloud-init", "properties": {"location": "de/fra", "imageAliases": ["ubuntu:latest"]}},
+				{"id": "no-cloud-init", "properties": {"location": "de/fra", "imageAliases": ["windows:latest"]}}
+			]}`))
+		case "/images/cloud-init":
+			w.Write([]byte(`{"id": "cloud-init", "properties": {"name": "ubuntu", "cloudInit": "V1"}}`))
+		case "/images/no-cloud-init":
+			w.Write([]byte(`{"id": "no-cloud-init", "properties": {"name": "windows", "cloudInit": "NONE"}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	config := Config{Token: "token", Endpoint: server.URL}
+	client, err := config.Client("0.12")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	for _, tc := range []struct {
+		image, alias string
+		supported    bool
+	}{
+		{"cloud-init", "", true},
+		{"no-cloud-init", "", false},
+		{"", "ubuntu:latest", true},
+		{"", "windows:latest", false},
+		{"", "unknown:latest", true},
+	} {
+		err := checkImageCloudInit(client, "dc", tc.image, tc.alias)
+		if tc.supported && err != nil {
+			t.Errorf("expected image %q alias %q to support cloud-init, got %s", tc.image, tc.alias, err)
+		}
+		if !tc.supported && err == nil {
+			t.Errorf("expected image %q alias %q not to support cloud-init", tc.image, tc.alias)
+		}
+	}
+}
+
+func TestAccProfitBricksVolume_FromSnapshot(t *testing.T) {
+	var volume profitbricks.Volume
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDProfitBricksVolumeDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckProfitbricksVolumeConfig_fromSnapshot,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProfitBricksVolumeExists("profitbricks_volume.restored", &volume),
+					resource.TestCheckResourceAttrPair("profitbricks_volume.restored", "image_name", "profitbricks_snapshot.golden", "id"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckDProfitBricksVolumeDestroyCheck(s *terraform.State) error {
 	client := testAccProvider.Meta().(*profitbricks.Client)
 	for _, rs := range s.RootModule().Resources {
@@ -173,3 +384,73 @@ resource "profitbricks_volume" "database_volume" {
   disk_type = "HDD"
   bus = "VIRTIO"
 }`
+
+const testAccCheckProfitbricksVolumeConfig_fromSnapshot = `
+resource "profitbricks_datacenter" "foobar" {
+	name       = "volume-test"
+	location = "us/las"
+}
+
+resource "profitbricks_server" "webserver" {
+  name = "webserver"
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  cores = 1
+  ram = 1024
+  availability_zone = "ZONE_1"
+  cpu_family = "AMD_OPTERON"
+	image_name = "ubuntu:14.04"
+	image_password = "K3tTj8G14a3EgKyNeeiY"
+  volume {
+    name = "system"
+    size = 5
+    disk_type = "HDD"
+  }
+}
+
+resource "profitbricks_snapshot" "golden" {
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  volume_id = "${profitbricks_server.webserver.boot_volume}"
+  name = "golden"
+}
+
+resource "profitbricks_volume" "restored" {
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  server_id = "${profitbricks_server.webserver.id}"
+  image_name = "${profitbricks_snapshot.golden.id}"
+  name = "restored"
+  size = 5
+  disk_type = "HDD"
+  bus = "VIRTIO"
+}`
+
+const testAccCheckProfitbricksVolumeConfig_resize = `
+resource "profitbricks_datacenter" "foobar" {
+	name       = "volume-test"
+	location = "us/las"
+}
+
+resource "profitbricks_server" "webserver" {
+  name = "webserver"
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  cores = 1
+  ram = 1024
+  availability_zone = "ZONE_1"
+  cpu_family = "AMD_OPTERON"
+	image_name = "ubuntu:14.04"
+	image_password = "K3tTj8G14a3EgKyNeeiY"
+  volume {
+    name = "system"
+    size = 5
+    disk_type = "HDD"
+  }
+}
+
+resource "profitbricks_volume" "database_volume" {
+  datacenter_id = "${profitbricks_datacenter.foobar.id}"
+  server_id = "${profitbricks_server.webserver.id}"
+  licence_type = "OTHER"
+  name = "database"
+  size = %d
+  disk_type = "HDD"
+  bus = "VIRTIO"
+}`
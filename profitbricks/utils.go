@@ -1,38 +1,133 @@
 package profitbricks
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"net"
+	"regexp"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	profitbricks "github.com/profitbricks/profitbricks-sdk-go/v5"
 )
 
-func resourceProfitBricksResourceImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceProfitBricksLanImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	parts := strings.Split(d.Id(), "/")
 	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-		return nil, fmt.Errorf("Invalid import id %q. Expecting {datacenter}/{resource}", d.Id())
+		return nil, fmt.Errorf("Invalid import id %q. Expecting {datacenter}/{lan}", d.Id())
 	}
 
+	client := meta.(*profitbricks.Client)
+	lan, err := client.GetLan(parts[0], parts[1])
+
+	if err != nil {
+		if apiError, ok := err.(profitbricks.ApiError); ok {
+			if apiError.HttpStatusCode() == 404 {
+				d.SetId("")
+				return nil, fmt.Errorf("Unable to find LAN %q in datacenter %q", parts[1], parts[0])
+			}
+		}
+		return nil, fmt.Errorf("Unable to retreive LAN %q: %w", parts[1], err)
+	}
+
+	log.Printf("[INFO] LAN found: %+v", lan)
+
 	d.Set("datacenter_id", parts[0])
-	d.SetId(parts[1])
+	d.SetId(lan.ID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceProfitBricksIPBlockImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*profitbricks.Client)
+	ipblock, err := client.GetIPBlock(d.Id())
+
+	if err != nil {
+		if apiError, ok := err.(profitbricks.ApiError); ok {
+			if apiError.HttpStatusCode() == 404 {
+				d.SetId("")
+				return nil, fmt.Errorf("Unable to find IP block %q", d.Id())
+			}
+		}
+		return nil, fmt.Errorf("Unable to retreive IP block %q: %w", d.Id(), err)
+	}
+
+	log.Printf("[INFO] IP block found: %+v", ipblock)
+
+	d.SetId(ipblock.ID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceProfitBricksDatacenterImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*profitbricks.Client)
+	datacenter, err := client.GetDatacenter(d.Id())
+
+	if err != nil {
+		if apiError, ok := err.(profitbricks.ApiError); ok {
+			if apiError.HttpStatusCode() == 404 {
+				d.SetId("")
+				return nil, fmt.Errorf("Unable to find datacenter %q", d.Id())
+			}
+		}
+		return nil, fmt.Errorf("Unable to retreive datacenter %q: %w", d.Id(), err)
+	}
+
+	log.Printf("[INFO] Datacenter found: %+v", datacenter)
+
+	d.SetId(datacenter.ID)
+	d.Set("name", datacenter.Properties.Name)
+	d.Set("location", datacenter.Properties.Location)
+	d.Set("description", datacenter.Properties.Description)
+
+	log.Printf("[INFO] Importing datacenter %q...", d.Id())
 
 	return []*schema.ResourceData{d}, nil
 }
 
 func resourceProfitBricksServerImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	parts := strings.Split(d.Id(), "/")
-	if len(parts) > 4 || len(parts) < 3 || parts[0] == "" || parts[1] == "" {
-		return nil, fmt.Errorf("Invalid import id %q. Expecting {datacenter}/{server}/{primary_nic} or {datacenter}/{server}/{primary_nic}/{firewall}", d.Id())
+	if len(parts) > 4 || len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("Invalid import id %q. Expecting {datacenter}/{server}, {datacenter}/{server}/{primary_nic} or {datacenter}/{server}/{primary_nic}/{firewall}", d.Id())
 	}
 
-	d.Set("datacenter_id", parts[0])
-	d.Set("primary_nic", parts[2])
-	if len(parts) > 3 {
-		d.Set("firewallrule_id", parts[3])
+	dcId := parts[0]
+	serverId := parts[1]
+
+	client := meta.(*profitbricks.Client)
+	server, err := client.GetServer(dcId, serverId)
+
+	if err != nil {
+		if apiError, ok := err.(profitbricks.ApiError); ok {
+			if apiError.HttpStatusCode() == 404 {
+				d.SetId("")
+				return nil, fmt.Errorf("Unable to find server %q in datacenter %q", serverId, dcId)
+			}
+		}
+		return nil, fmt.Errorf("Unable to retreive server %q: %w", serverId, err)
+	}
+
+	log.Printf("[INFO] Server found: %+v", server)
+
+	d.Set("datacenter_id", dcId)
+	d.SetId(server.ID)
+
+	if len(parts) > 2 && parts[2] != "" {
+		d.Set("primary_nic", parts[2])
+		if len(parts) > 3 && parts[3] != "" {
+			d.Set("firewallrule_id", parts[3])
+		}
+	} else if server.Entities != nil && server.Entities.Nics != nil && len(server.Entities.Nics.Items) > 0 {
+		// no nic given, fall back to the first nic of the server and its first firewall rule
+		nic := server.Entities.Nics.Items[0]
+		d.Set("primary_nic", nic.ID)
+		if nic.Entities != nil && nic.Entities.FirewallRules != nil && len(nic.Entities.FirewallRules.Items) > 0 {
+			d.Set("firewallrule_id", nic.Entities.FirewallRules.Items[0].ID)
+		}
 	}
-	d.SetId(parts[1])
+
+	log.Printf("[INFO] Importing server %q...", d.Id())
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -57,12 +152,7 @@ func resourceProfitBricksK8sClusterImport(d *schema.ResourceData, meta interface
 	d.Set("k8s_version", cluster.Properties.K8sVersion)
 
 	if cluster.Properties.MaintenanceWindow != nil {
-		d.Set("maintenance_window", []map[string]string{
-			{
-				"day_of_the_week": cluster.Properties.MaintenanceWindow.DayOfTheWeek,
-				"time":            cluster.Properties.MaintenanceWindow.Time,
-			},
-		})
+		d.Set("maintenance_window", flattenMaintenanceWindow(cluster.Properties.MaintenanceWindow))
 		log.Printf("[INFO] Setting maintenance window for k8s cluster %s to %+v...", d.Id(), cluster.Properties.MaintenanceWindow)
 	}
 
@@ -128,12 +218,7 @@ func resourceProfitBricksK8sNodepoolImport(d *schema.ResourceData, meta interfac
 	}
 
 	if k8sNodepool.Properties.MaintenanceWindow != nil {
-		d.Set("maintenance_window", []map[string]string{
-			{
-				"day_of_the_week": k8sNodepool.Properties.MaintenanceWindow.DayOfTheWeek,
-				"time":            k8sNodepool.Properties.MaintenanceWindow.Time,
-			},
-		})
+		d.Set("maintenance_window", flattenMaintenanceWindow(k8sNodepool.Properties.MaintenanceWindow))
 		log.Printf("[INFO] Setting maintenance window for k8s node pool %s to %+v...", d.Id(), k8sNodepool.Properties.MaintenanceWindow)
 	}
 
@@ -223,7 +308,7 @@ func resourceProfitBricksBackupUnitImport(d *schema.ResourceData, meta interface
 	contractResources, cErr := client.GetContractResources()
 
 	if cErr != nil {
-		return nil, fmt.Errorf("Error while fetching contract resources for backup unit %q: %s", d.Id(), cErr)
+		return nil, fmt.Errorf("Error while fetching contract resources for backup unit %q: %w", d.Id(), cErr)
 	}
 
 	d.Set("login", fmt.Sprintf("%s-%d", backupUnit.Properties.Name, int64(contractResources.Properties.PBContractNumber)))
@@ -231,6 +316,27 @@ func resourceProfitBricksBackupUnitImport(d *schema.ResourceData, meta interface
 	return []*schema.ResourceData{d}, nil
 }
 
+func resourceProfitBricksSnapshotImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*profitbricks.Client)
+	snapshot, err := client.GetSnapshot(d.Id())
+
+	if err != nil {
+		if apiError, ok := err.(profitbricks.ApiError); ok {
+			if apiError.HttpStatusCode() == 404 {
+				d.SetId("")
+				return nil, fmt.Errorf("Unable to find snapshot %q", d.Id())
+			}
+		}
+		return nil, fmt.Errorf("Unable to retreive snapshot %q: %w", d.Id(), err)
+	}
+
+	log.Printf("[INFO] Snapshot found: %+v", snapshot)
+
+	d.SetId(snapshot.ID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceProfitBricksS3KeyImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	parts := strings.Split(d.Id(), "/")
 
@@ -261,27 +367,113 @@ func resourceProfitBricksS3KeyImport(d *schema.ResourceData, meta interface{}) (
 
 func resourceProfitBricksFirewallImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	parts := strings.Split(d.Id(), "/")
-	if len(parts) != 4 || parts[0] == "" || parts[1] == "" {
-		return nil, fmt.Errorf("Invalid import id %q. Expecting {datacenter}/{server}/{nic}/{firewall}", d.Id())
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("Invalid import id %q. Expecting {datacenter}/{server}/{nic}/{firewall}, got %d segments instead of 4", d.Id(), len(parts))
 	}
+	for i, name := range []string{"datacenter", "server", "nic", "firewall"} {
+		if parts[i] == "" {
+			return nil, fmt.Errorf("Invalid import id %q. The %s id is empty, expecting {datacenter}/{server}/{nic}/{firewall}", d.Id(), name)
+		}
+	}
+
+	client := meta.(*profitbricks.Client)
+	fw, err := getFirewallRuleWithExtras(client, parts[0], parts[1], parts[2], parts[3])
+
+	if err != nil {
+		if apiError, ok := err.(profitbricks.ApiError); ok {
+			if apiError.HttpStatusCode() == 404 {
+				d.SetId("")
+				return nil, fmt.Errorf("Unable to find firewall rule %q of nic %q in server %q of datacenter %q", parts[3], parts[2], parts[1], parts[0])
+			}
+		}
+		return nil, fmt.Errorf("Unable to retreive firewall rule %q: %w", parts[3], err)
+	}
+
+	log.Printf("[INFO] Firewall rule found: %+v", fw)
 
 	d.Set("datacenter_id", parts[0])
 	d.Set("server_id", parts[1])
 	d.Set("nic_id", parts[2])
-	d.SetId(parts[3])
+	d.SetId(fw.ID)
 
 	return []*schema.ResourceData{d}, nil
 }
 
 func resourceProfitBricksNicImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	parts := strings.Split(d.Id(), "/")
-	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
 		return nil, fmt.Errorf("Invalid import id %q. Expecting {datacenter}/{server}/{nic}", d.Id())
 	}
 
+	client := meta.(*profitbricks.Client)
+	nic, err := client.GetNic(parts[0], parts[1], parts[2])
+
+	if err != nil {
+		if apiError, ok := err.(profitbricks.ApiError); ok {
+			if apiError.HttpStatusCode() == 404 {
+				d.SetId("")
+				return nil, fmt.Errorf("Unable to find nic %q of server %q in datacenter %q", parts[2], parts[1], parts[0])
+			}
+		}
+		return nil, fmt.Errorf("Unable to retreive nic %q: %w", parts[2], err)
+	}
+
+	log.Printf("[INFO] Nic found: %+v", nic)
+
 	d.Set("datacenter_id", parts[0])
 	d.Set("server_id", parts[1])
-	d.SetId(parts[2])
+	d.Set("restart_server_on_attach", false)
+	d.SetId(nic.ID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceProfitBricksVolumeImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("Invalid import id %q. Expecting {datacenter}/{volume}", d.Id())
+	}
+
+	dcId := parts[0]
+	volumeId := parts[1]
+
+	client := meta.(*profitbricks.Client)
+	_, err := client.GetVolume(dcId, volumeId)
+
+	if err != nil {
+		if apiError, ok := err.(profitbricks.ApiError); ok {
+			if apiError.HttpStatusCode() == 404 {
+				d.SetId("")
+				return nil, fmt.Errorf("Unable to find volume %q in datacenter %q", volumeId, dcId)
+			}
+		}
+		return nil, fmt.Errorf("Unable to retreive volume %q: %w", volumeId, err)
+	}
+
+	// volumes do not reference the server they are attached to, so the servers of the data center are searched
+	servers, err := client.ListServers(dcId)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to list the servers of datacenter %q: %w", dcId, err)
+	}
+
+	serverId := ""
+	for _, server := range servers.Items {
+		volumes, err := client.ListAttachedVolumes(dcId, server.ID)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to list the volumes attached to server %q: %w", server.ID, err)
+		}
+		for _, volume := range volumes.Items {
+			if volume.ID == volumeId {
+				serverId = server.ID
+			}
+		}
+	}
+
+	log.Printf("[INFO] Importing volume %q attached to server %q...", volumeId, serverId)
+
+	d.Set("datacenter_id", dcId)
+	d.Set("server_id", serverId)
+	d.SetId(volumeId)
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -294,6 +486,182 @@ func convertSlice(slice []interface{}) []string {
 	return s
 }
 
+// expandStringMap returns a map attribute as a map of strings, an empty map when it is not set
+func expandStringMap(v interface{}) map[string]string {
+	result := map[string]string{}
+	for key, value := range v.(map[string]interface{}) {
+		result[key] = value.(string)
+	}
+	return result
+}
+
+// validateIPv4Address accepts a single IPv4 address. The Cloud API v5 has no IPv6 support, v6 addresses
+// would only be refused when applying
+func validateIPv4Address(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value == "" {
+		return
+	}
+	ip := net.ParseIP(value)
+	switch {
+	case ip == nil:
+		errors = append(errors, fmt.Errorf("%q must be an IPv4 address, got %q", k, value))
+	case ip.To4() == nil:
+		errors = append(errors, fmt.Errorf("%q must be an IPv4 address, got the IPv6 address %q which is not supported by the API", k, value))
+	}
+	return
+}
+
+// validateIPv4Addresses accepts a comma separated list of IPv4 addresses
+func validateIPv4Addresses(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value == "" {
+		return
+	}
+	for _, address := range strings.Split(value, ",") {
+		_, errs := validateIPv4Address(address, k)
+		errors = append(errors, errs...)
+	}
+	return
+}
+
+// validateCIDR accepts a network in CIDR notation, e.g. 192.168.0.0/24
+func validateCIDR(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if _, _, err := net.ParseCIDR(value); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a network in CIDR notation like 192.168.0.0/24, got %q", k, value))
+	}
+	return
+}
+
+// validateNodeCount accepts the positive number of nodes of a k8s node pool
+func validateNodeCount(v interface{}, k string) (ws []string, errors []error) {
+	if value := v.(int); value < 1 {
+		errors = append(errors, fmt.Errorf("%q must be at least 1, got %d", k, value))
+	}
+	return
+}
+
+// k8sName matches the name of a kubernetes label or annotation key, and a label value
+var k8sName = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+
+// k8sPrefix matches the optional DNS subdomain prefix of a kubernetes label or annotation key
+var k8sPrefix = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
+// validateK8sKey checks a label or annotation key is a name of up to 63 characters, optionally prefixed
+// by a DNS subdomain of up to 253 characters and a slash
+func validateK8sKey(key string) error {
+	name := key
+	if i := strings.Index(key, "/"); i >= 0 {
+		prefix := key[:i]
+		name = key[i+1:]
+		if len(prefix) > 253 || !k8sPrefix.MatchString(prefix) {
+			return fmt.Errorf("the prefix of key %q must be a DNS subdomain of at most 253 characters", key)
+		}
+	}
+	if len(name) > 63 || !k8sName.MatchString(name) {
+		return fmt.Errorf("key %q must be at most 63 alphanumeric characters, '-', '_' or '.', beginning and ending with an alphanumeric character", key)
+	}
+	return nil
+}
+
+// validateK8sLabels accepts a map of kubernetes labels, their values are empty or names like the keys
+func validateK8sLabels(v interface{}, k string) (ws []string, errors []error) {
+	for key, value := range v.(map[string]interface{}) {
+		if err := validateK8sKey(key); err != nil {
+			errors = append(errors, fmt.Errorf("%q: %w", k, err))
+		}
+		if label := value.(string); label != "" && (len(label) > 63 || !k8sName.MatchString(label)) {
+			errors = append(errors, fmt.Errorf("%q: the value %q of %q must be at most 63 alphanumeric characters, '-', '_' or '.', beginning and ending with an alphanumeric character", k, label, key))
+		}
+	}
+	return
+}
+
+// validateK8sAnnotations accepts a map of kubernetes annotations, only their keys are restricted
+func validateK8sAnnotations(v interface{}, k string) (ws []string, errors []error) {
+	for key := range v.(map[string]interface{}) {
+		if err := validateK8sKey(key); err != nil {
+			errors = append(errors, fmt.Errorf("%q: %w", k, err))
+		}
+	}
+	return
+}
+
+// maintenanceWindowDays are the days of the week a maintenance window can start on
+var maintenanceWindowDays = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+
+// maintenanceWindowTime matches the HH:MM:SS start time of a maintenance window, the API adds a Z as it is in UTC
+var maintenanceWindowTime = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]:[0-5][0-9]Z?$`)
+
+// maintenanceWindowSchema is the maintenance_window block shared by k8s clusters and node pools. The API
+// picks a window when none is given, so it is computed as well
+func maintenanceWindowSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "A maintenance window comprise of a day of the week and a time for maintenance to be allowed",
+		Optional:    true,
+		Computed:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"time": {
+					Type:        schema.TypeString,
+					Description: "A clock time in the day when maintenance is allowed, as HH:MM:SS in UTC",
+					Required:    true,
+					DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+						return strings.TrimSuffix(old, "Z") == strings.TrimSuffix(new, "Z")
+					},
+					ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+						if !maintenanceWindowTime.MatchString(v.(string)) {
+							errors = append(errors, fmt.Errorf("%q must be a time formatted as HH:MM:SS, got %q", k, v.(string)))
+						}
+						return
+					},
+				},
+				"day_of_the_week": {
+					Type:        schema.TypeString,
+					Description: "Day of the week when maintenance is allowed",
+					Required:    true,
+					ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+						for _, day := range maintenanceWindowDays {
+							if v.(string) == day {
+								return
+							}
+						}
+						errors = append(errors, fmt.Errorf("%q must be one of %s, got %q", k, strings.Join(maintenanceWindowDays, ", "), v.(string)))
+						return
+					},
+				},
+			},
+		},
+	}
+}
+
+// expandMaintenanceWindow returns the configured maintenance window, or nil if there is none
+func expandMaintenanceWindow(d *schema.ResourceData) *profitbricks.MaintenanceWindow {
+	if _, ok := d.GetOk("maintenance_window.0"); !ok {
+		return nil
+	}
+	return &profitbricks.MaintenanceWindow{
+		DayOfTheWeek: d.Get("maintenance_window.0.day_of_the_week").(string),
+		Time:         d.Get("maintenance_window.0.time").(string),
+	}
+}
+
+// flattenMaintenanceWindow returns the maintenance_window block of mw
+func flattenMaintenanceWindow(mw *profitbricks.MaintenanceWindow) []interface{} {
+	if mw == nil {
+		return []interface{}{}
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"time":            mw.Time,
+			"day_of_the_week": mw.DayOfTheWeek,
+		},
+	}
+}
+
 func diffSlice(slice1 []string, slice2 []string) []string {
 	var diff []string
 
@@ -321,3 +689,129 @@ func diffSlice(slice1 []string, slice2 []string) []string {
 
 	return diff
 }
+
+// propertyChanged reports whether an attribute has to be sent to the API, on create all configured attributes are sent
+func propertyChanged(d *schema.ResourceData, attr string) bool {
+	if d.IsNewResource() {
+		_, ok := d.GetOkExists(attr)
+		return ok
+	}
+	return d.HasChange(attr)
+}
+
+// changedProperties maps the changed attributes to their API property names, for use as a PATCH body
+func changedProperties(d *schema.ResourceData, attrs map[string]string) map[string]interface{} {
+	properties := map[string]interface{}{}
+	for attr, property := range attrs {
+		if propertyChanged(d, attr) {
+			properties[property] = d.Get(attr)
+		}
+	}
+	return properties
+}
+
+// clearedProperties returns properties as a PATCH body with the given properties set to "". The sdk models
+// leave out empty strings, so they cannot clear e.g. a name by themselves
+func clearedProperties(properties interface{}, cleared ...string) (map[string]interface{}, error) {
+	data, err := json.Marshal(properties)
+	if err != nil {
+		return nil, err
+	}
+	body := map[string]interface{}{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, err
+	}
+	for _, property := range cleared {
+		body[property] = ""
+	}
+	return body, nil
+}
+
+// clearedName returns the name property when the name attribute is changed to an empty one
+func clearedName(d *schema.ResourceData, attr string) []string {
+	if d.HasChange(attr) && d.Get(attr).(string) == "" {
+		return []string{"name"}
+	}
+	return nil
+}
+
+// hotPlugFlag returns the changed value of a capability flag, flags which are turned off have to be sent as well
+func hotPlugFlag(d *schema.ResourceData, attr string) *bool {
+	if !propertyChanged(d, attr) {
+		return nil
+	}
+	value := d.Get(attr).(bool)
+	return &value
+}
+
+// withHotPlugSchema adds the given capability flags to a resource schema, they default to what the API reports
+func withHotPlugSchema(s map[string]*schema.Schema, flags map[string]string) map[string]*schema.Schema {
+	for attr := range flags {
+		s[attr] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Computed: true,
+		}
+	}
+	return s
+}
+
+func resourceProfitBricksGroupMembershipImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("Invalid import id %q. Expecting {groupId}/{userId}", d.Id())
+	}
+
+	d.Set("group_id", parts[0])
+	d.Set("user_id", parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceProfitBricksLabelImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	var resourceType, dcId, resourceId, key string
+	switch {
+	case len(parts) == 4 && (parts[0] == "server" || parts[0] == "volume"):
+		resourceType, dcId, resourceId, key = parts[0], parts[1], parts[2], parts[3]
+	case len(parts) == 3 && parts[0] != "server" && parts[0] != "volume":
+		resourceType, resourceId, key = parts[0], parts[1], parts[2]
+	default:
+		return nil, fmt.Errorf("Invalid import id %q. Expecting {resource_type}/{resource_id}/{key}, or {resource_type}/{datacenter}/{resource_id}/{key} for servers and volumes", d.Id())
+	}
+	if resourceId == "" || key == "" || (len(parts) == 4 && dcId == "") {
+		return nil, fmt.Errorf("Invalid import id %q, it contains an empty segment", d.Id())
+	}
+
+	path, err := labelsPath(resourceType, dcId, resourceId)
+	if err != nil {
+		return nil, err
+	}
+
+	client := meta.(*profitbricks.Client)
+	label, err := getLabel(client, path, key)
+	if err != nil {
+		if apiError, ok := err.(profitbricks.ApiError); ok {
+			if apiError.HttpStatusCode() == 404 {
+				d.SetId("")
+				return nil, fmt.Errorf("Unable to find label %q on %s %q", key, resourceType, resourceId)
+			}
+		}
+		return nil, fmt.Errorf("Unable to retreive label %q: %w", key, err)
+	}
+
+	log.Printf("[INFO] Label found: %+v", label)
+
+	d.Set("resource_type", resourceType)
+	d.Set("datacenter_id", dcId)
+	d.Set("resource_id", resourceId)
+	d.Set("key", key)
+	if label.ID != "" {
+		d.SetId(label.ID)
+	} else {
+		d.SetId(fmt.Sprintf("%s/%s", path, key))
+	}
+
+	return []*schema.ResourceData{d}, nil
+}